@@ -0,0 +1,137 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addDescriptionFlag string
+	addNameFlag        string
+	addTagFlag         []string
+	addProjectFlag     bool
+)
+
+var addCmd = &cobra.Command{
+	Use:   "add [flags] -- <snippet>",
+	Short: "add a snippet without the TUI.",
+	Long: `Add a snippet given as an argument, for scripts and dotfiles bootstrap.
+With "-" instead of a snippet, every non-blank line of stdin is added as a snippet.
+Snippets must be one-liners with valid placeholders (${{name}} or ${{name:default}});
+when any is invalid, nothing is added and the command fails.`,
+	Example: `  linippet add --name logs --description "recent commits" --tag git -- 'git log --oneline -n ${{count:10}}'
+  cat snippets.txt | linippet add --tag team -`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("must be specified one quoted snippet or -. [example: linippet add -- 'ls -la']")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addDescriptionFlag, _ := cmd.Flags().GetString("description")
+		addNameFlag, _ := cmd.Flags().GetString("name")
+		addTagFlag, _ := cmd.Flags().GetStringSlice("tag")
+		addProjectFlag, _ := cmd.Flags().GetBool("project")
+		tags := linippet.ParseTags(strings.Join(addTagFlag, ","))
+
+		var snippets []string
+		var errs []error
+		if args[0] == "-" {
+			lines, err := readLines(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			for i, line := range lines {
+				if strings.TrimSpace(line) == "" {
+					continue
+				}
+				if err := validateNewSnippet(line); err != nil {
+					errs = append(errs, fmt.Errorf("line %d: %w", i+1, err))
+				}
+				snippets = append(snippets, line)
+			}
+		} else {
+			if err := validateNewSnippet(args[0]); err != nil {
+				errs = append(errs, err)
+			}
+			snippets = args
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+		if len(snippets) == 0 {
+			fmt.Println("Cannot create blank snippet.")
+			return nil
+		}
+		if addNameFlag != "" && len(snippets) > 1 {
+			return fmt.Errorf("must be specified --name for only one snippet, stdin has %d", len(snippets))
+		}
+		if err := checkNewName(linippet.Linippet{Name: addNameFlag}); err != nil {
+			return err
+		}
+
+		store, err := newSnippetStore(addProjectFlag)
+		if err != nil {
+			return err
+		}
+		for _, s := range snippets {
+			if _, err := store.Add(linippet.Linippet{
+				Snippet:     s,
+				Name:        addNameFlag,
+				Description: addDescriptionFlag,
+				Tags:        tags,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(snippets) == 1 {
+			fmt.Println("Success to create snippet!")
+		} else {
+			fmt.Printf("Success to create %d snippets!\n", len(snippets))
+		}
+		return nil
+	},
+}
+
+// readLines returns the lines of r.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// validateNewSnippet checks that s can be saved as a snippet.
+func validateNewSnippet(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("cannot create blank snippet")
+	}
+	if err := snippet.ValidateSnippet(s); err != nil {
+		return err
+	}
+	return snippet.ValidatePlaceholders(s)
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.Flags().StringVarP(&addDescriptionFlag, "description", "d", "", "description of the snippet")
+	addCmd.Flags().StringVarP(&addNameFlag, "name", "n", "", "unique name referring to the snippet like its ID")
+	addCmd.Flags().StringSliceVarP(&addTagFlag, "tag", "t", nil, "tag of the snippet (repeatable)")
+	_ = addCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	addCmd.Flags().BoolVarP(&addProjectFlag, "project", "p", false, "save to the project snippet file")
+}
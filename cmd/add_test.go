@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestAddStdin(t *testing.T) {
+	setupSnippets(t, linippet.Linippet{Id: "a", Snippet: "pwd"})
+	if _, err := executeCmdWithInput(t, "ls -la\n\r\n  \necho ${{name:x}}\r\n", "add", "--tag", "team", "-"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := listSnippets(t), []string{"pwd", "ls -la", "echo ${{name:x}}"}; !slices.Equal(got, want) {
+		t.Errorf("snippets = %q, want %q", got, want)
+	}
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linippets, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := linippets[2].Tags; !slices.Equal(tags, []string{"team"}) {
+		t.Errorf("tags = %q, want [team]", tags)
+	}
+}
+
+func TestAddInvalidPlaceholder(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		args    []string
+		wantErr string
+	}{
+		{name: "argument", args: []string{"add", "--", "echo ${{bad"}, wantErr: "invalid placeholder"},
+		{name: "stdin", input: "ls\necho ${{a b}}\n", args: []string{"add", "-"}, wantErr: "line 2: invalid placeholder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSnippets(t, linippet.Linippet{Id: "a", Snippet: "pwd"})
+			_, err := executeCmdWithInput(t, tt.input, tt.args...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if code := exitCode(err); code == 0 {
+				t.Errorf("exit status = 0, want non-zero")
+			}
+			if got, want := listSnippets(t), []string{"pwd"}; !slices.Equal(got, want) {
+				t.Errorf("snippets = %q, want %q", got, want)
+			}
+		})
+	}
+}
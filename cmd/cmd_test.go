@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// setupSnippets points the global snippet file at a temporary directory
+// holding linippets, and moves into a repository without project snippets.
+func setupSnippets(t *testing.T, linippets ...linippet.Linippet) {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	t.Setenv(linippet.ENV_NAME, filepath.Join(root, "data"))
+	t.Setenv(linippet.PATH_ENV_NAME, "")
+	t.Setenv(linippet.STORE_ENV_NAME, "")
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+	if len(linippets) == 0 {
+		return
+	}
+	b, err := json.Marshal(linippets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, "data", linippet.LINIPPET_DATA_FILE_NAME)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// executeCmd runs linippet with args and returns what it wrote to the output
+// of the command. Flags are reset afterwards, as rootCmd is shared by tests.
+func executeCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	return executeCmdWithInput(t, "", args...)
+}
+
+// executeCmdWithInput runs linippet with args as executeCmd does, reading
+// input from stdin.
+func executeCmdWithInput(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	rootCmd.SetIn(strings.NewReader(input))
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() {
+		rootCmd.SetIn(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+		resetFlags(rootCmd)
+	})
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			_ = slice.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// listSnippets returns the snippets of the default store.
+func listSnippets(t *testing.T) []string {
+	t.Helper()
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linippets, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snippets := make([]string, len(linippets))
+	for i, l := range linippets {
+		snippets[i] = l.Snippet
+	}
+	return snippets
+}
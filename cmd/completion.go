@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+// completeSnippets suggests the snippets not in args yet: their name, or
+// their ID when they have none, described by their description or snippet.
+func completeSnippets(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	linippets, err := store.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var completions []cobra.Completion
+	for _, l := range linippets {
+		ref := l.Name
+		if ref == "" {
+			ref = l.Id
+		}
+		if !strings.HasPrefix(ref, toComplete) || slices.Contains(args, ref) {
+			continue
+		}
+		description := l.Description
+		if description == "" {
+			description = l.Snippet
+		}
+		completions = append(completions, cobra.CompletionWithDesc(ref, description))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeOneSnippet suggests snippets for commands taking a single one.
+func completeOneSnippet(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeSnippets(cmd, args, toComplete)
+}
+
+// completeArgs suggests name= for each argument of the snippet given as the
+// first argument, described by its default, and name=default once the name
+// is typed.
+func completeArgs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	target, err := linippet.Find(store, args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	given, _ := cmd.Flags().GetStringArray("arg")
+	var completions []cobra.Completion
+	for _, arg := range snippet.UniqueArgs(target.Snippet) {
+		if name, _, ok := strings.Cut(toComplete, "="); ok {
+			if name == arg.Name && arg.Default != "" {
+				return []cobra.Completion{arg.Name + "=" + arg.Default}, cobra.ShellCompDirectiveNoFileComp
+			}
+			continue
+		}
+		if !strings.HasPrefix(arg.Name, toComplete) || slices.ContainsFunc(given, func(pair string) bool {
+			return strings.HasPrefix(pair, arg.Name+"=")
+		}) {
+			continue
+		}
+		description := "required"
+		if arg.Default != "" {
+			description = "default: " + arg.Default
+		}
+		completions = append(completions, cobra.CompletionWithDesc(arg.Name+"=", description))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeTags suggests the tags of every snippet.
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	linippets, err := store.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var tags []string
+	for _, l := range linippets {
+		for _, tag := range l.Tags {
+			if strings.HasPrefix(tag, toComplete) && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "snippets by name or id", args: []string{"run", ""}, want: []string{"logs\trecent", "7d01\tls", ":4"}},
+		{name: "snippets by prefix", args: []string{"edit", "7"}, want: []string{"7d01\tls", ":4"}},
+		{name: "one snippet only", args: []string{"edit", "logs", ""}, want: []string{":4"}},
+		{name: "snippets not given yet", args: []string{"remove", "logs", ""}, want: []string{"7d01\tls", ":4"}},
+		{name: "args", args: []string{"run", "logs", "--arg", ""}, want: []string{"count=\tdefault: 10", "ref=\trequired", ":6"}},
+		{name: "default of an arg", args: []string{"run", "logs", "--arg", "count="}, want: []string{"count=10", ":4"}},
+		{name: "no default of an arg", args: []string{"run", "logs", "--arg", "ref="}, want: []string{":6"}},
+		{name: "args not given yet", args: []string{"run", "logs", "--arg", "count=5", "--arg", ""}, want: []string{"ref=\trequired", ":6"}},
+		{name: "args of an unknown snippet", args: []string{"run", "missing", "--arg", ""}, want: []string{":4"}},
+		{name: "tags", args: []string{"--tag", ""}, want: []string{"fs", "git", ":4"}},
+		{name: "tags by prefix", args: []string{"add", "--tag", "g"}, want: []string{"git", ":4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSnippets(t,
+				linippet.Linippet{Id: "16601a", Name: "logs", Snippet: "git log -n ${{count:10}} ${{ref}}", Description: "recent", Tags: []string{"git"}},
+				linippet.Linippet{Id: "7d01", Snippet: "ls", Tags: []string{"git", "fs"}},
+			)
+			out, err := executeCmd(t, append([]string{"__complete"}, tt.args...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := strings.Join(tt.want, "\n") + "\n"; out != want {
+				t.Errorf("__complete %q =\n%swant\n%s", tt.args, out, want)
+			}
+		})
+	}
+}
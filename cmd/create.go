@@ -11,16 +11,30 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	projectFlag bool
+	initialFlag string
+)
+
 // createCmd represents the create command
 var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "create new a snippet",
-	Long:  `Create new a snippet command.`,
+	Long: `Create new a snippet command.
+With --project, the snippet is saved to the nearest project snippet file (.linippet.json),
+which is created at the git root when there is none.
+With --initial, the snippet field is filled with a command to edit, such as the current command line.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		t := tui.NewCreateTui()
+		projectFlag, _ := cmd.Flags().GetBool("project")
+		initialFlag, _ := cmd.Flags().GetString("initial")
+		store, err := newSnippetStore(projectFlag)
+		if err != nil {
+			return err
+		}
+		t := tui.NewCreateTui(initialFlag)
 		t.SetAction()
 		if err := t.StartApp(); err != nil {
-			panic(err)
+			return err
 		}
 		if !t.Submit {
 			return nil
@@ -29,7 +43,16 @@ var createCmd = &cobra.Command{
 			fmt.Println("Cannot create blank snippet.")
 			return nil
 		}
-		if err := linippet.AddLinippet(t.Result); err != nil {
+		created := linippet.Linippet{
+			Snippet:     t.Result,
+			Description: t.Description,
+			Tags:        t.Tags,
+			Name:        t.Name,
+		}
+		if err := checkNewName(created); err != nil {
+			return err
+		}
+		if _, err := store.Add(created); err != nil {
 			return err
 		}
 		fmt.Println("Success to create snippet!")
@@ -37,6 +60,34 @@ var createCmd = &cobra.Command{
 	},
 }
 
+// newSnippetStore returns the store new snippets are added to: the nearest
+// project snippet file when project is set, and the default store otherwise.
+func newSnippetStore(project bool) (linippet.Store, error) {
+	if !project {
+		return linippet.NewDefaultStore()
+	}
+	projectPath, err := linippet.ProjectJsonPath()
+	if err != nil {
+		return nil, err
+	}
+	return linippet.NewJsonStore(projectPath, false), nil
+}
+
+// checkNewName checks the name of a new snippet against every snippet of the
+// default store.
+func checkNewName(l linippet.Linippet) error {
+	if l.Name == "" {
+		return nil
+	}
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		return err
+	}
+	return linippet.CheckName(store, l)
+}
+
 func init() {
 	rootCmd.AddCommand(createCmd)
+	createCmd.Flags().BoolVarP(&projectFlag, "project", "p", false, "save to the project snippet file")
+	createCmd.Flags().StringVar(&initialFlag, "initial", "", "initial snippet to edit")
 }
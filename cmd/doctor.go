@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/spf13/cobra"
+)
+
+var repairFlag bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "check your snippet files.",
+	Long: `Check every snippet file linippet reads and report the broken ones.
+With --repair, a broken snippet file is backed up next to it and replaced with
+every snippet which can still be parsed from it. Read-only collections and
+markdown snippet files are never repaired.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repairFlag, _ := cmd.Flags().GetBool("repair")
+		statuses, err := linippet.CheckFiles()
+		if err != nil {
+			return err
+		}
+		broken := 0
+		for _, status := range statuses {
+			if status.Err == nil {
+				fmt.Printf("ok     %s (%d snippets)\n", status.Path, status.Count)
+				continue
+			}
+			var parseErr *linippet.ParseError
+			if !errors.As(status.Err, &parseErr) {
+				fmt.Printf("error  %s: %v\n", status.Path, status.Err)
+				broken++
+				continue
+			}
+			fmt.Printf("broken %s:%d:%d: %v\n", parseErr.Path, parseErr.Line, parseErr.Column, parseErr.Err)
+			if !repairFlag || !status.Repairable {
+				broken++
+				continue
+			}
+			backupPath, salvaged, err := linippet.RepairFile(status.Path)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("       repaired: salvaged %d snippets, the broken file is backed up to %s\n", salvaged, backupPath)
+		}
+		if broken > 0 {
+			if !repairFlag {
+				return fmt.Errorf("%d snippet files have a problem, run `linippet doctor --repair` to salvage broken ones", broken)
+			}
+			return fmt.Errorf("%d snippet files have a problem", broken)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&repairFlag, "repair", false, "back up and salvage broken snippet files")
+}
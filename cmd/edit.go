@@ -5,22 +5,77 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/muleyuck/linippet/internal/linippet"
 	"github.com/muleyuck/linippet/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	editSnippetFlag     string
+	editDescriptionFlag string
+	editNameFlag        string
+	editTagFlag         []string
+)
+
 var editCmd = &cobra.Command{
-	Use:   "edit",
+	Use:   "edit [name|id]",
 	Short: "edit a snippet.",
-	Long:  "Edit snippet which be chosen from your snippets list",
+	Long: `Edit snippet which be chosen from your snippets list.
+Given a name, an ID, or a prefix of a single ID, the snippet is edited directly: the flags
+replace its fields without the TUI, and the snippet form is opened without them.
+Snippets of read-only collections (LINIPPET_PATH) are copied to your snippets when saved.`,
+	Example: `  linippet edit
+  linippet edit logs
+  linippet edit 3f2a --snippet 'git log --oneline -n ${{count:20}}' --tag git`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeOneSnippet,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		t := tui.NewEditTui()
-		t.LazyLoadLinippet()
+		flagsChanged := cmd.Flags().Changed("snippet") || cmd.Flags().Changed("description") ||
+			cmd.Flags().Changed("tag") || cmd.Flags().Changed("name")
+		if len(args) == 0 && flagsChanged {
+			return fmt.Errorf("must be specified the snippet to edit with flags. [example: linippet edit 3f2a --snippet 'ls -la']")
+		}
+		store, err := linippet.NewDefaultStore()
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return editFromList(store)
+		}
+		target, err := linippet.Find(store, args[0])
+		if err != nil {
+			return err
+		}
+		edited := target
+		if target.ReadOnly {
+			// the original keeps its name, so its copy cannot have it
+			edited.Name = ""
+		}
+		if flagsChanged {
+			if cmd.Flags().Changed("snippet") {
+				edited.Snippet, _ = cmd.Flags().GetString("snippet")
+				if err := validateNewSnippet(edited.Snippet); err != nil {
+					return err
+				}
+			}
+			if cmd.Flags().Changed("description") {
+				edited.Description, _ = cmd.Flags().GetString("description")
+			}
+			if cmd.Flags().Changed("name") {
+				edited.Name, _ = cmd.Flags().GetString("name")
+			}
+			if cmd.Flags().Changed("tag") {
+				tags, _ := cmd.Flags().GetStringSlice("tag")
+				edited.Tags = linippet.ParseTags(strings.Join(tags, ","))
+			}
+			return saveEdited(store, edited)
+		}
+		t := tui.NewFormTui(target)
 		t.SetAction()
 		if err := t.StartApp(); err != nil {
-			panic(err)
+			return err
 		}
 		if !t.Submit {
 			return nil
@@ -29,14 +84,67 @@ var editCmd = &cobra.Command{
 			fmt.Println("Cannot save blank snippet.")
 			return nil
 		}
-		if err := linippet.UpdateLinippet(t.SelectId, t.Result); err != nil {
+		edited.Snippet = t.Result
+		edited.Description = t.Description
+		edited.Tags = t.Tags
+		edited.Name = t.Name
+		return saveEdited(store, edited)
+	},
+}
+
+// editFromList edits the snippet chosen from the list.
+func editFromList(store linippet.Store) error {
+	t := tui.NewEditTui(store)
+	t.LazyLoadLinippet()
+	t.SetAction()
+	if err := t.StartApp(); err != nil {
+		return err
+	}
+	if !t.Submit {
+		return nil
+	}
+	if len(t.Result) <= 0 {
+		fmt.Println("Cannot save blank snippet.")
+		return nil
+	}
+	edited := t.SelectedLinippet()
+	edited.Snippet = t.Result
+	edited.Description = t.Description
+	edited.Tags = t.Tags
+	edited.Name = t.Name
+	return saveEdited(store, edited)
+}
+
+// saveEdited saves edited over the snippet having its Id. A read-only
+// snippet is copied to the global snippet file instead, and the copy cannot
+// keep the name of the original.
+func saveEdited(store linippet.Store, edited linippet.Linippet) error {
+	if edited.ReadOnly {
+		edited.Id = ""
+		if err := linippet.CheckName(store, edited); err != nil {
+			return fmt.Errorf("%w, give the copy of a read-only snippet another name or none", err)
+		}
+		if _, err := store.Add(edited); err != nil {
 			return err
 		}
-		fmt.Println("Success to edit snippet!")
+		fmt.Println("Success to copy snippet to your snippets!")
 		return nil
-	},
+	}
+	if err := linippet.CheckName(store, edited); err != nil {
+		return err
+	}
+	if err := store.Update(edited); err != nil {
+		return err
+	}
+	fmt.Println("Success to edit snippet!")
+	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(editCmd)
+	editCmd.Flags().StringVarP(&editSnippetFlag, "snippet", "s", "", "new snippet")
+	editCmd.Flags().StringVarP(&editDescriptionFlag, "description", "d", "", "new description")
+	editCmd.Flags().StringVarP(&editNameFlag, "name", "n", "", "new name, or empty to remove it")
+	editCmd.Flags().StringSliceVarP(&editTagFlag, "tag", "t", nil, "new tags, replacing the current ones (repeatable)")
+	_ = editCmd.RegisterFlagCompletionFunc("tag", completeTags)
 }
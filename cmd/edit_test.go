@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestEditReadOnlyCopy(t *testing.T) {
+	setupSnippets(t)
+	team := filepath.Join(t.TempDir(), "team.json")
+	if err := os.WriteFile(team, []byte(`[{"id":"16601a","name":"logs","snippet":"git log"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(linippet.PATH_ENV_NAME, team)
+
+	if _, err := executeCmd(t, "edit", "logs", "--description", "mine"); err != nil {
+		t.Fatal(err)
+	}
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	linippets, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linippets) != 2 {
+		t.Fatalf("linippets = %+v, want the copy and the original", linippets)
+	}
+	// the copy is listed first, without the name the original keeps
+	if copied := linippets[0]; copied.ReadOnly || copied.Name != "" || copied.Description != "mine" {
+		t.Errorf("copy = %+v", copied)
+	}
+	if original := linippets[1]; !original.ReadOnly || original.Name != "logs" {
+		t.Errorf("original = %+v", original)
+	}
+}
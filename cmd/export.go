@@ -0,0 +1,37 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/spf13/cobra"
+)
+
+var exportTagFlag []string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export snippets for other tools.",
+	Long:  `Export your snippets, including project snippets and collections, in the formats of other tools.`,
+}
+
+// exportedLinippets returns the linippets to export, filtered by --tag.
+func exportedLinippets(cmd *cobra.Command) (linippet.Linippets, error) {
+	exportTagFlag, _ := cmd.Flags().GetStringSlice("tag")
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		return nil, err
+	}
+	linippets, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	return linippets.FilterByTags(exportTagFlag), nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.PersistentFlags().StringSliceVarP(&exportTagFlag, "tag", "t", nil, "only export snippets having the tag (repeatable)")
+	_ = exportCmd.RegisterFlagCompletionFunc("tag", completeTags)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/muleyuck/linippet/internal/exporter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	groupByFlag  string
+	titleFlag    string
+	templateFlag string
+)
+
+var exportMarkdownCmd = &cobra.Command{
+	Use:   "markdown",
+	Short: "export snippets as a markdown cheat sheet.",
+	Long: `Export snippets as a markdown cheat sheet to stdout, grouped by tag or by the snippet file
+they come from, with the arguments of their placeholders and defaults as a table.
+--template replaces the layout with a Go text/template file executed with:
+  .Title     the title
+  .Groups    the groups, each having .Name (empty when not grouped) and .Snippets
+  .Snippets  the snippets, each having .Id, .Name, .Snippet, .Description, .Tags,
+             .Generators (argument names to commands), .Source, .ReadOnly and .Args,
+             the arguments each having .Name and .Default
+and the functions "code" (inline code), "fence" (fenced code block) and "cell" (table cell escape).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		groupByFlag, _ := cmd.Flags().GetString("group-by")
+		titleFlag, _ := cmd.Flags().GetString("title")
+		templateFlag, _ := cmd.Flags().GetString("template")
+		tmpl := ""
+		if templateFlag != "" {
+			b, err := os.ReadFile(templateFlag)
+			if err != nil {
+				return err
+			}
+			tmpl = string(b)
+		}
+		linippets, err := exportedLinippets(cmd)
+		if err != nil {
+			return err
+		}
+		sheet, err := exporter.NewCheatSheet(titleFlag, linippets, groupByFlag)
+		if err != nil {
+			return err
+		}
+		return exporter.Markdown(os.Stdout, sheet, tmpl)
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportMarkdownCmd)
+	exportMarkdownCmd.Flags().StringVar(&groupByFlag, "group-by", exporter.GROUP_BY_TAG, `group snippets by "tag", "source" or "none"`)
+	exportMarkdownCmd.Flags().StringVar(&titleFlag, "title", "Snippets", "title of the cheat sheet")
+	exportMarkdownCmd.Flags().StringVar(&templateFlag, "template", "", "text/template file replacing the layout")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/muleyuck/linippet/internal/exporter"
+	"github.com/spf13/cobra"
+)
+
+var exportWarpCmd = &cobra.Command{
+	Use:   "warp <dir>",
+	Short: "export snippets as Warp workflows.",
+	Long: `Export snippets as Warp workflow files, one per snippet, into a directory.
+Placeholders ${{arg:default}} are converted to {{arg}} with the default_value of the argument.
+The description of a snippet, or the snippet itself when it has none, becomes the name of the workflow.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		linippets, err := exportedLinippets(cmd)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(args[0], 0755); err != nil {
+			return err
+		}
+		used := make(map[string]bool)
+		for _, l := range linippets {
+			out, err := exporter.Warp(l)
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(args[0], exporter.FileName(exporter.WarpName(l), exporter.WARP_EXT, used))
+			if err := os.WriteFile(path, out, 0644); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Success to export %d snippets to %s!\n", len(linippets), args[0])
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportWarpCmd)
+}
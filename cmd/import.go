@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/muleyuck/linippet/internal/importer"
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/spf13/cobra"
+)
+
+var dryRunFlag bool
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import snippets from other tools.",
+	Long: `Import snippets from other tools into your snippets.
+Snippets which are already in your snippets are reported and skipped.`,
+}
+
+// readImportPath converts the snippets at path with readDir when it is a
+// directory, and with readFile otherwise.
+func readImportPath(
+	path string,
+	readDir func(fsys fs.FS) (*importer.Result, error),
+	readFile func(name string, r io.Reader) (*importer.Result, error),
+) (*importer.Result, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return readDir(os.DirFS(path))
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readFile(path, file)
+}
+
+// saveImported adds the imported linippets which are not in the store yet,
+// reporting the warnings of the import and the duplicates and invalid
+// snippets skipped.
+func saveImported(cmd *cobra.Command, result *importer.Result) error {
+	dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+	store, err := linippet.NewDefaultStore()
+	if err != nil {
+		return err
+	}
+	existing, err := store.List()
+	if err != nil {
+		return err
+	}
+	fresh, duplicates := importer.SplitDuplicates(result.Linippets, existing)
+	for _, duplicate := range duplicates {
+		fmt.Printf("skip duplicate: %s\n", duplicate.Snippet)
+	}
+	var valid linippet.Linippets
+	for _, l := range fresh {
+		if err := validateNewSnippet(l.Snippet); err != nil {
+			fmt.Fprintf(os.Stderr, "skip invalid: %q: %v\n", l.Snippet, err)
+			continue
+		}
+		valid = append(valid, l)
+	}
+	invalid := len(fresh) - len(valid)
+	if dryRunFlag {
+		for _, l := range valid {
+			fmt.Printf("import: %s\n", l.Snippet)
+		}
+		fmt.Printf("%d snippets would be imported, %d duplicates and %d invalid skipped.\n", len(valid), len(duplicates), invalid)
+		return nil
+	}
+	for _, l := range valid {
+		if _, err := store.Add(l); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Success to import %d snippets! (%d duplicates and %d invalid skipped)\n", len(valid), len(duplicates), invalid)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "only show what would be imported")
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/muleyuck/linippet/internal/importer"
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var limitFlag int
+
+// defaultHistoryFiles are read, relative to the home directory, when no
+// history file is given.
+var defaultHistoryFiles = []string{".zsh_history", ".bash_history"}
+
+var importHistoryCmd = &cobra.Command{
+	Use:   "history [file...]",
+	Short: "choose snippets from your shell history.",
+	Long: `Rank the commands of your shell history by frequency and choose which to keep as snippets.
+Without files, ~/.zsh_history and ~/.bash_history are read. Both bash and zsh extended history are supported.
+Commands run as often are ordered by how recently they were run, counted from the end of each file, as files of
+different shells cannot be compared by time.
+Commands which are already in your snippets are left out.
+In the list, Tab marks a command and Enter imports the marked commands, or the current one when none is marked.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limitFlag, _ := cmd.Flags().GetInt("limit")
+		dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+		paths, err := historyPaths(args)
+		if err != nil {
+			return err
+		}
+		var histories [][]string
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			fileCommands, err := importer.History(bytes.NewReader(data), importer.IsZshHistory(path, data))
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			histories = append(histories, fileCommands)
+		}
+
+		store, err := linippet.NewDefaultStore()
+		if err != nil {
+			return err
+		}
+		existing, err := store.List()
+		if err != nil {
+			return err
+		}
+		counts := make(map[string]int)
+		var candidates linippet.Linippets
+		for _, entry := range importer.RankHistory(histories...) {
+			candidates = append(candidates, linippet.Linippet{Id: strconv.Itoa(len(candidates)), Snippet: entry.Command})
+			counts[entry.Command] = entry.Count
+		}
+		candidates, _ = importer.SplitDuplicates(candidates, existing)
+		if limitFlag > 0 && len(candidates) > limitFlag {
+			candidates = candidates[:limitFlag]
+		}
+		if len(candidates) == 0 {
+			fmt.Println("linippet: There are no new commands in your history")
+			return nil
+		}
+		if dryRunFlag {
+			for _, l := range candidates {
+				fmt.Fprintf(cmd.OutOrStdout(), "%6d  %s\n", counts[l.Snippet], l.Snippet)
+			}
+			return nil
+		}
+
+		t := tui.NewMultiSelectTui(linippet.NewMemoryStore(candidates...))
+		t.SetDescriptionFunc(func(l linippet.Linippet) string {
+			return fmt.Sprintf("%d times", counts[l.Snippet])
+		})
+		t.LazyLoadLinippet()
+		t.SetAction()
+		if err := t.StartApp(); err != nil {
+			return err
+		}
+		if !t.Submit {
+			return nil
+		}
+		selected := t.SelectedLinippets()
+		for i := range selected {
+			selected[i].Id = ""
+		}
+		return saveImported(cmd, &importer.Result{Linippets: selected})
+	},
+}
+
+// historyPaths returns the given history files, or the default ones which
+// exist.
+func historyPaths(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, name := range defaultHistoryFiles {
+		path := filepath.Join(homeDir, name)
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no history file is found, specify one. [example: linippet import history ~/.zsh_history]")
+	}
+	return paths, nil
+}
+
+func init() {
+	importCmd.AddCommand(importHistoryCmd)
+	importHistoryCmd.Flags().IntVar(&limitFlag, "limit", 500, "maximum number of commands to choose from (0 for no limit)")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestImportHistoryFiles(t *testing.T) {
+	setupSnippets(t, linippet.Linippet{Id: "a", Snippet: "ls"})
+	dir := t.TempDir()
+	zshPath := filepath.Join(dir, ".zsh_history")
+	bashPath := filepath.Join(dir, ".bash_history")
+	if err := os.WriteFile(zshPath, []byte(": 1700000000:0;git status\n: 1700000001:0;pwd\n: 1700000002:0;ls\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bashPath, []byte("make\npwd\n#1700000003\ndocker ps\ntop\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := executeCmd(t, "import", "history", "--dry-run", zshPath, bashPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pwd is run in both, ls is already a snippet, and the others are ordered
+	// by how recently they were run in their own file
+	want := "     2  pwd\n" +
+		"     1  top\n" +
+		"     1  docker ps\n" +
+		"     1  git status\n" +
+		"     1  make\n"
+	if out != want {
+		t.Errorf("output =\n%swant\n%s", out, want)
+	}
+}
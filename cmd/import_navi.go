@@ -0,0 +1,30 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"github.com/muleyuck/linippet/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+var importNaviCmd = &cobra.Command{
+	Use:   "navi <dir>",
+	Short: "import snippets from navi cheat files.",
+	Long: `Import snippets from every navi cheat file (*.cheat) in a directory, or from a cheat file.
+"% tags" and "# description" lines are kept, and variables <var> are converted to ${{var}}.
+Variable definitions "$ var: command" are kept as metadata of the snippets using them,
+as linippet does not run them to suggest values.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := readImportPath(args[0], importer.Navi, importer.NaviCheat)
+		if err != nil {
+			return err
+		}
+		return saveImported(cmd, result)
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importNaviCmd)
+}
@@ -0,0 +1,36 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/muleyuck/linippet/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+var importPetCmd = &cobra.Command{
+	Use:   "pet <file>",
+	Short: "import snippets from pet's snippet.toml.",
+	Long: `Import snippets from pet's snippet.toml, usually ~/.config/pet/snippet.toml.
+Parameters <param> and <param=default> are converted to ${{param}} and ${{param:default}}.
+Only the first choice of a multiple choice parameter is kept, as its default.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		result, err := importer.Pet(file)
+		if err != nil {
+			return err
+		}
+		return saveImported(cmd, result)
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importPetCmd)
+}
@@ -0,0 +1,29 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"github.com/muleyuck/linippet/internal/importer"
+	"github.com/spf13/cobra"
+)
+
+var importWarpCmd = &cobra.Command{
+	Use:   "warp <dir>",
+	Short: "import snippets from Warp workflows.",
+	Long: `Import snippets from every Warp workflow file (*.yaml, *.yml) in a directory, or from a workflow file.
+Arguments {{arg}} are converted to ${{arg:default}} with their default_value.
+The name of a workflow becomes the description of the snippet when it has none.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := readImportPath(args[0], importer.Warp, importer.WarpWorkflow)
+		if err != nil {
+			return err
+		}
+		return saveImported(cmd, result)
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importWarpCmd)
+}
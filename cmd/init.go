@@ -13,9 +13,10 @@ import (
 
 // initCmd represents the init command
 var initCmd = &cobra.Command{
-	Use:   "init",
-	Short: "initialize linppet",
-	Long:  "set environment and key bind to initialize linippet",
+	Use:       "init",
+	Short:     "initialize linppet",
+	Long:      "set environment and key bind to initialize linippet",
+	ValidArgs: []cobra.Completion{"bash", "zsh", "fish", "nu"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return fmt.Errorf("must be specified Shell name. [example: linippet init bash]")
@@ -23,10 +24,16 @@ var initCmd = &cobra.Command{
 		shellName := args[0]
 		switch shellName {
 		case "zsh":
-			fmt.Printf("%s", scripts.InitializeZShellScript)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s", scripts.InitializeZShellScript)
 			return nil
 		case "bash":
-			fmt.Printf("%s", scripts.InitializeBashScript)
+			fmt.Fprintf(cmd.OutOrStdout(), "%s", scripts.InitializeBashScript)
+			return nil
+		case "fish":
+			fmt.Fprintf(cmd.OutOrStdout(), "%s", scripts.InitializeFishScript)
+			return nil
+		case "nu":
+			fmt.Fprintf(cmd.OutOrStdout(), "%s", scripts.InitializeNushellScript)
 			return nil
 		}
 		return fmt.Errorf("%s is Unsupported Shell", shellName)
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitScripts(t *testing.T) {
+	tests := []struct {
+		shell string
+		// check returns the syntax check of the script at path, run when the
+		// shell is installed
+		check func(path string) *exec.Cmd
+		want  string
+	}{
+		{shell: "bash", check: func(path string) *exec.Cmd { return exec.Command("bash", "-n", path) }, want: "linippet_apply()"},
+		{shell: "zsh", check: func(path string) *exec.Cmd { return exec.Command("zsh", "-n", path) }, want: "linippet_apply()"},
+		{shell: "fish", check: func(path string) *exec.Cmd { return exec.Command("fish", "--no-execute", path) }, want: "function lip"},
+		{shell: "nu", check: func(path string) *exec.Cmd {
+			return exec.Command("nu", "--no-config-file", "--commands", fmt.Sprintf("use %q *", path))
+		}, want: "export def lip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs([]string{"init", tt.shell})
+			t.Cleanup(func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetArgs(nil)
+			})
+			if err := rootCmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Fatalf("init %s does not contain %q:\n%s", tt.shell, tt.want, out.String())
+			}
+
+			path := filepath.Join(t.TempDir(), "init."+tt.shell)
+			check := tt.check(path)
+			if _, err := exec.LookPath(check.Path); err != nil {
+				t.Skipf("%s is not installed", check.Path)
+			}
+			if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if output, err := check.CombinedOutput(); err != nil {
+				t.Errorf("%s: %v\n%s", strings.Join(check.Args, " "), err, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/spf13/cobra"
+)
+
+var toFlag string
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [<from> <to>]",
+	Short: "convert snippets between the JSON file and the directory layout.",
+	Long: `Convert snippets between a JSON snippet file and a snippet directory,
+which keeps one markdown file per snippet. Ids, descriptions and tags are kept.
+With --to, your global snippets are converted to the given layout: "dir" or "json".
+Otherwise, the snippets at <from> are converted to <to>, which is a directory
+unless it ends with ".json". The source is left as it is.`,
+	Args: cobra.MatchAll(cobra.RangeArgs(0, 2), func(cmd *cobra.Command, args []string) error {
+		toFlag, _ := cmd.Flags().GetString("to")
+		if (toFlag == "") == (len(args) == 0) || len(args) == 1 {
+			return fmt.Errorf("must be specified either --to or <from> <to>. [example: linippet migrate --to dir]")
+		}
+		return nil
+	}),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		toFlag, _ := cmd.Flags().GetString("to")
+		var from, to string
+		if toFlag != "" {
+			fromKind := linippet.STORE_KIND_JSON
+			if toFlag == linippet.STORE_KIND_JSON {
+				fromKind = linippet.STORE_KIND_DIR
+			}
+			var err error
+			if to, err = linippet.GlobalStorePath(toFlag); err != nil {
+				return err
+			}
+			if from, err = linippet.GlobalStorePath(fromKind); err != nil {
+				return err
+			}
+		} else {
+			from, to = args[0], args[1]
+		}
+		count, err := linippet.Migrate(from, to)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Success to migrate %d snippets to %s!\n", count, to)
+		if toFlag != "" {
+			fmt.Printf("Set %s=%s to use them.\n", linippet.STORE_ENV_NAME, toFlag)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVar(&toFlag, "to", "", `convert your global snippets to "dir" or "json"`)
+}
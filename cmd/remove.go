@@ -5,34 +5,101 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/muleyuck/linippet/internal/linippet"
 	"github.com/muleyuck/linippet/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+var yesFlag bool
+
 var removeCmd = &cobra.Command{
-	Use:   "remove",
+	Use:   "remove [name|id...]",
 	Short: "remove a snippet.",
-	Long:  "Remove a snippet which be chosen from your snippets list",
+	Long: `Remove a snippet which be chosen from your snippets list.
+Given names, IDs, or prefixes of a single ID each, those snippets are removed after
+confirmation, which --yes skips.
+Snippets of read-only collections (LINIPPET_PATH) cannot be removed.`,
+	Example: `  linippet remove
+  linippet remove 3f2a
+  linippet remove --yes 3f2a 7d01`,
+	ValidArgsFunction: completeSnippets,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		t := tui.NewRemoveTui()
-		t.LazyLoadLinippet()
-		t.SetAction()
-		if err := t.StartApp(); err != nil {
-			panic(err)
-		}
-		if !t.Submit {
-			return nil
+		yesFlag, _ := cmd.Flags().GetBool("yes")
+		if len(args) == 0 && yesFlag {
+			return fmt.Errorf("must be specified the snippets to remove with --yes. [example: linippet remove --yes 3f2a]")
 		}
-		if err := linippet.RemoveLinippet(t.SelectId); err != nil {
+		store, err := linippet.NewDefaultStore()
+		if err != nil {
 			return err
 		}
-		fmt.Println("Success to remove snippet!")
+		if len(args) == 0 {
+			return removeFromList(store)
+		}
+		targets := make(linippet.Linippets, 0, len(args))
+		for _, ref := range args {
+			target, err := linippet.Find(store, ref)
+			if err != nil {
+				return err
+			}
+			if target.ReadOnly {
+				return fmt.Errorf("%w: %s", linippet.ErrReadOnly, target.Id)
+			}
+			// a snippet may be given twice, e.g. by its name and its ID
+			if slices.ContainsFunc(targets, func(l linippet.Linippet) bool { return l.Id == target.Id }) {
+				continue
+			}
+			targets = append(targets, target)
+		}
+		if !yesFlag {
+			snippets := make([]string, len(targets))
+			for i, target := range targets {
+				snippets[i] = target.Snippet
+			}
+			t := tui.NewConfirmTui("Remove the following snippet?\n\n" + strings.Join(snippets, "\n") + "\n")
+			t.SetAction()
+			if err := t.StartApp(); err != nil {
+				return err
+			}
+			if !t.Submit {
+				return nil
+			}
+		}
+		for _, target := range targets {
+			if err := store.Remove(target.Id); err != nil {
+				return err
+			}
+		}
+		if len(targets) == 1 {
+			fmt.Println("Success to remove snippet!")
+		} else {
+			fmt.Printf("Success to remove %d snippets!\n", len(targets))
+		}
 		return nil
 	},
 }
 
+// removeFromList removes the snippet chosen from the list.
+func removeFromList(store linippet.Store) error {
+	t := tui.NewRemoveTui(store)
+	t.LazyLoadLinippet()
+	t.SetAction()
+	if err := t.StartApp(); err != nil {
+		return err
+	}
+	if !t.Submit {
+		return nil
+	}
+	if err := store.Remove(t.SelectId); err != nil {
+		return err
+	}
+	fmt.Println("Success to remove snippet!")
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(removeCmd)
+	removeCmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "remove without confirmation")
 }
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestRemoveYes(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "by name", args: []string{"logs"}, want: []string{"ls", "pwd"}},
+		{name: "by name and id prefix", args: []string{"logs", "1660"}, want: []string{"ls", "pwd"}},
+		{name: "several", args: []string{"1660", "7d01"}, want: []string{"pwd"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSnippets(t,
+				linippet.Linippet{Id: "16601a", Name: "logs", Snippet: "git log"},
+				linippet.Linippet{Id: "7d01", Snippet: "ls"},
+				linippet.Linippet{Id: "9e00", Snippet: "pwd"},
+			)
+			if _, err := executeCmd(t, append([]string{"remove", "--yes"}, tt.args...)...); err != nil {
+				t.Fatal(err)
+			}
+			if got := listSnippets(t); !slices.Equal(got, tt.want) {
+				t.Errorf("snippets = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
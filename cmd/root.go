@@ -4,9 +4,15 @@ Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 
+	"github.com/muleyuck/linippet/internal/exporter"
+	"github.com/muleyuck/linippet/internal/fuzzy_search"
 	"github.com/muleyuck/linippet/internal/linippet"
 	"github.com/muleyuck/linippet/internal/snippet"
 	"github.com/muleyuck/linippet/internal/tui"
@@ -17,8 +23,19 @@ import (
 var (
 	versionFlag bool
 	listFlag    bool
+	tagFlag     []string
+	formatFlag  string
+	queryFlag   string
+	select1Flag bool
+	exit0Flag   bool
 )
 
+// EXIT_CODE_NO_MATCH is the exit status when --exit-0 finds no snippet.
+const EXIT_CODE_NO_MATCH = 2
+
+// errNoMatch is returned when --exit-0 finds no snippet. It is not printed.
+var errNoMatch = errors.New("no snippet matches the query")
+
 var rootCmd = &cobra.Command{
 	Use:   "linippet",
 	Short: "Choose your snippet and output stdout",
@@ -26,13 +43,31 @@ var rootCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		versionFlag, _ := cmd.Flags().GetBool("version")
 		listFlag, _ := cmd.Flags().GetBool("list")
+		tagFlag, _ := cmd.Flags().GetStringSlice("tag")
+		formatFlag, _ := cmd.Flags().GetString("format")
+		queryFlag, _ := cmd.Flags().GetString("query")
+		select1Flag, _ := cmd.Flags().GetBool("select-1")
+		exit0Flag, _ := cmd.Flags().GetBool("exit-0")
 		if versionFlag {
 			fmt.Printf("linippet %s", scripts.AppVersion)
-		} else if listFlag {
-			linippets, err := linippet.ReadLinippets()
+			return nil
+		}
+		if formatFlag != "" && !listFlag {
+			return fmt.Errorf("must be specified --list with --format. [example: linippet --list --format json]")
+		}
+		store, err := linippet.NewDefaultStore()
+		if err != nil {
+			return err
+		}
+		if listFlag {
+			linippets, err := store.List()
 			if err != nil {
 				return err
 			}
+			linippets = linippets.FilterByTags(tagFlag)
+			if formatFlag != "" {
+				return exporter.List(os.Stdout, linippets, formatFlag)
+			}
 			if len(linippets) <= 0 {
 				fmt.Println("linippet: There are no snippets")
 				return nil
@@ -41,11 +76,27 @@ var rootCmd = &cobra.Command{
 				fmt.Printf("%d : %s\n", i+1, linippet.Snippet)
 			}
 		} else {
-			t := tui.NewRootTui()
+			if select1Flag || exit0Flag {
+				matches, err := searchLinippets(store, tagFlag, queryFlag)
+				if err != nil {
+					return err
+				}
+				if len(matches) == 0 && exit0Flag {
+					cmd.SilenceErrors = true
+					cmd.SilenceUsage = true
+					return errNoMatch
+				}
+				if len(matches) == 1 && select1Flag {
+					return printSelected(cmd.OutOrStdout(), matches[0])
+				}
+			}
+			t := tui.NewRootTui(store)
+			t.SetTags(tagFlag)
+			t.SetQuery(queryFlag)
 			t.LazyLoadLinippet()
 			t.SetAction()
 			if err := t.StartApp(); err != nil {
-				panic(err)
+				return err
 			}
 			if err := snippet.ValidateSnippet(t.Result); err != nil {
 				return err
@@ -56,14 +107,74 @@ var rootCmd = &cobra.Command{
 	},
 }
 
-func Execute() {
-	err := rootCmd.Execute()
+// searchLinippets returns the linippets of store having tags which match
+// query, as the list shows them when it opens: all of them for a blank query.
+func searchLinippets(store linippet.Store, tags []string, query string) (linippet.Linippets, error) {
+	linippets, err := store.List()
 	if err != nil {
-		os.Exit(1)
+		return nil, err
+	}
+	results := fuzzy_search.Search(context.Background(), query, linippets.FilterByTags(tags))
+	matches := make(linippet.Linippets, len(results))
+	for i, result := range results {
+		matches[i] = result.Linippet
+	}
+	return matches, nil
+}
+
+// printSelected prints target to w without the list, asking only for its
+// arguments when it has some.
+func printSelected(w io.Writer, target linippet.Linippet) error {
+	if err := snippet.ValidateSnippet(target.Snippet); err != nil {
+		return err
+	}
+	if len(snippet.ExtractSnippetArgsWithDefaults(target.Snippet)) == 0 {
+		fmt.Fprintln(w, target.Snippet)
+		return nil
+	}
+	t := tui.NewArgsTui(target)
+	t.SetAction()
+	if err := t.StartApp(); err != nil {
+		return err
+	}
+	if !t.Submit {
+		return nil
+	}
+	fmt.Fprintln(w, t.Result)
+	return nil
+}
+
+func Execute() {
+	if code := exitCode(rootCmd.Execute()); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// exitCode returns the exit status of linippet failing with err.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if errors.Is(err, errNoMatch) {
+		return EXIT_CODE_NO_MATCH
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		// a snippet executed by run failed
+		return exitErr.ExitCode()
 	}
+	return 1
 }
 
 func init() {
 	rootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false, "version")
 	rootCmd.Flags().BoolVarP(&listFlag, "list", "l", false, "show snippet list")
+	rootCmd.Flags().StringVar(&formatFlag, "format", "", `format of --list: "json", "jsonl", "tsv" (id, snippet, description, tags, name) or a text/template such as '{{.Id}} {{.Snippet}}'`)
+	rootCmd.Flags().StringSliceVarP(&tagFlag, "tag", "t", nil, "only show snippets having the tag (repeatable)")
+	rootCmd.Flags().StringVarP(&queryFlag, "query", "q", "", "initial search query")
+	rootCmd.Flags().BoolVarP(&select1Flag, "select-1", "1", false, "output the only matching snippet without the list")
+	rootCmd.Flags().BoolVarP(&exit0Flag, "exit-0", "0", false, fmt.Sprintf("exit with status %d without the list when no snippet matches", EXIT_CODE_NO_MATCH))
+	_ = rootCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	_ = rootCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(
+		[]cobra.Completion{exporter.FORMAT_JSON, exporter.FORMAT_JSONL, exporter.FORMAT_TSV}, cobra.ShellCompDirectiveNoFileComp))
 }
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/fuzzy_search"
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+var rootTestLinippets = []linippet.Linippet{
+	{Id: "a", Snippet: "git log", Tags: []string{"git"}},
+	{Id: "b", Snippet: "ls -la", Tags: []string{"fs"}},
+	{Id: "c", Snippet: "pwd", Tags: []string{"fs"}},
+}
+
+func TestSearchLinippets(t *testing.T) {
+	tests := []struct {
+		name  string
+		tags  []string
+		query string
+		want  []string
+	}{
+		{name: "query", query: "ls", want: []string{"ls -la"}},
+		{name: "tag flag", tags: []string{"fs"}, want: []string{"ls -la", "pwd"}},
+		{name: "tag in query", query: "#fs", want: []string{"ls -la", "pwd"}},
+		{name: "tag flag and query", tags: []string{"fs"}, query: "pwd", want: []string{"pwd"}},
+		{name: "tag flag excluding the match", tags: []string{"git"}, query: "pwd", want: []string{}},
+		{name: "unknown tag in query", query: "#docker", want: []string{}},
+	}
+	store := linippet.NewMemoryStore(rootTestLinippets...)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := searchLinippets(store, tt.tags, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, len(matches))
+			for i, l := range matches {
+				got[i] = l.Snippet
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("searchLinippets(%q, %q) = %q, want %q", tt.tags, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSearchLinippetsBlankQuery checks that --select-1 and --exit-0 see what
+// the list shows for a blank query: every snippet having the tags.
+func TestSearchLinippetsBlankQuery(t *testing.T) {
+	store := linippet.NewMemoryStore(rootTestLinippets...)
+	for _, query := range []string{"", " ", "\t"} {
+		for _, tags := range [][]string{nil, {"fs"}} {
+			matches, err := searchLinippets(store, tags, query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			listed := fuzzy_search.Search(context.Background(), query, linippet.Linippets(rootTestLinippets).FilterByTags(tags))
+			if len(matches) != len(listed) || len(matches) != len(linippet.Linippets(rootTestLinippets).FilterByTags(tags)) {
+				t.Errorf("searchLinippets(%q, %q) = %d snippets, the list shows %d", tags, query, len(matches), len(listed))
+			}
+		}
+	}
+}
+
+func TestRootSelect1(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "query", args: []string{"--query", "ls"}, want: "ls -la\n"},
+		{name: "tag flag", args: []string{"--tag", "git"}, want: "git log\n"},
+		{name: "tag in query", args: []string{"--query", "#git"}, want: "git log\n"},
+		{name: "with exit-0", args: []string{"-0", "-q", "pwd"}, want: "pwd\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSnippets(t, rootTestLinippets...)
+			out, err := executeCmd(t, append([]string{"--select-1"}, tt.args...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != tt.want {
+				t.Errorf("output = %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootExit0(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "query", args: []string{"--query", "zzz"}},
+		{name: "tag flag", args: []string{"--tag", "git", "--query", "pwd"}},
+		{name: "tag in query", args: []string{"--query", "#docker"}},
+		{name: "with select-1", args: []string{"-1", "-q", "zzz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupSnippets(t, rootTestLinippets...)
+			out, err := executeCmd(t, append([]string{"--exit-0"}, tt.args...)...)
+			if code := exitCode(err); code != EXIT_CODE_NO_MATCH {
+				t.Errorf("exit status = %d (%v), want %d", code, err, EXIT_CODE_NO_MATCH)
+			}
+			if out != "" {
+				t.Errorf("output = %q, want none", out)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: 0},
+		{name: "no match", err: errNoMatch, want: EXIT_CODE_NO_MATCH},
+		{name: "failed snippet", err: exitErr, want: 3},
+		{name: "other error", err: errors.New("failed"), want: 1},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("%s: exitCode(%v) = %d, want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
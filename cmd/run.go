@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 muleyuck <takuty.008.awenite.1121@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	argFlag  []string
+	execFlag bool
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <name|id>",
+	Short: "fill a snippet's arguments without the TUI.",
+	Long: `Fill the placeholders of the snippet having the name or the ID, or a prefix of a single ID,
+with --arg name=value and print the result, or execute it with $SHELL with --exec.
+Arguments not given take their default, and the command fails listing the
+arguments having none. With --exec, the command exits with the status of the snippet.`,
+	Example: `  linippet run logs --arg count=20
+  linippet run 3f2a --arg host=db1 --arg port=5432 --exec`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("must be specified one snippet name or ID. [example: linippet run logs --arg count=20]")
+		}
+		return nil
+	},
+	ValidArgsFunction: completeOneSnippet,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		argFlag, _ := cmd.Flags().GetStringArray("arg")
+		execFlag, _ := cmd.Flags().GetBool("exec")
+		values, err := parseArgValues(argFlag)
+		if err != nil {
+			return err
+		}
+		store, err := linippet.NewDefaultStore()
+		if err != nil {
+			return err
+		}
+		target, err := linippet.Find(store, args[0])
+		if err != nil {
+			return err
+		}
+		result, err := snippet.FillSnippet(target.Snippet, values)
+		if err != nil {
+			return err
+		}
+		if !execFlag {
+			fmt.Println(result)
+			return nil
+		}
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "sh"
+		}
+		c := exec.Command(shell, "-c", result)
+		c.Stdin = os.Stdin
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		// the snippet reports its own errors, Execute exits with its status
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return c.Run()
+	},
+}
+
+// parseArgValues parses name=value pairs into a map by name.
+func parseArgValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("must be specified --arg as name=value. [example: --arg count=20]")
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().StringArrayVarP(&argFlag, "arg", "a", nil, "value of an argument as name=value (repeatable)")
+	_ = runCmd.RegisterFlagCompletionFunc("arg", completeArgs)
+	runCmd.Flags().BoolVarP(&execFlag, "exec", "x", false, "execute the snippet instead of printing it")
+}
@@ -0,0 +1,32 @@
+// Package exporter converts linippets into the formats of other tools.
+package exporter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var nonAlnumRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// maxFileNameLength is the maximum length of a file name made from a text,
+// without its extension.
+const maxFileNameLength = 60
+
+// FileName returns a file name made from text, ending with ext, which is not
+// used yet. The name is then added to used.
+func FileName(text, ext string, used map[string]bool) string {
+	base := strings.Trim(nonAlnumRegexp.ReplaceAllString(strings.ToLower(text), "_"), "_")
+	if len(base) > maxFileNameLength {
+		base = strings.TrimRight(base[:maxFileNameLength], "_")
+	}
+	if base == "" {
+		base = "snippet"
+	}
+	name := base + ext
+	for i := 2; used[name]; i++ {
+		name = base + "_" + strconv.Itoa(i) + ext
+	}
+	used[name] = true
+	return name
+}
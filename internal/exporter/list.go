@@ -0,0 +1,117 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+)
+
+// formats of a machine-readable snippet list
+const (
+	FORMAT_JSON  = "json"
+	FORMAT_JSONL = "jsonl"
+	FORMAT_TSV   = "tsv"
+)
+
+// ListSnippet is a linippet as it is listed, with the arguments of its
+// placeholders.
+type ListSnippet struct {
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+	Snippet     string    `json:"snippet"`
+	Description string    `json:"description"`
+	Tags        []string  `json:"tags"`
+	Source      string    `json:"source"`
+	ReadOnly    bool      `json:"read_only"`
+	Args        []ListArg `json:"args"`
+}
+
+// ListArg is an argument of a listed snippet. Default is empty when the
+// argument has none.
+type ListArg struct {
+	Name    string `json:"name"`
+	Default string `json:"default"`
+}
+
+func newListSnippet(l linippet.Linippet) ListSnippet {
+	args := []ListArg{}
+	for _, arg := range snippet.UniqueArgs(l.Snippet) {
+		args = append(args, ListArg{Name: arg.Name, Default: arg.Default})
+	}
+	tags := l.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return ListSnippet{
+		Id:          l.Id,
+		Name:        l.Name,
+		Snippet:     l.Snippet,
+		Description: l.Description,
+		Tags:        tags,
+		Source:      l.Source,
+		ReadOnly:    l.ReadOnly,
+		Args:        args,
+	}
+}
+
+// List writes linippets to w in format: a JSON array, one JSON object per
+// line, or tab separated id, snippet, description, comma separated tags and
+// name, one snippet per line, with tabs and new lines in fields replaced by
+// spaces. Any other format containing "{{" is a text/template executed with
+// each ListSnippet, followed by a new line.
+func List(w io.Writer, linippets linippet.Linippets, format string) error {
+	listed := make([]ListSnippet, len(linippets))
+	for i, l := range linippets {
+		listed[i] = newListSnippet(l)
+	}
+	switch format {
+	case FORMAT_JSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(listed)
+	case FORMAT_JSONL:
+		encoder := json.NewEncoder(w)
+		for _, s := range listed {
+			if err := encoder.Encode(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FORMAT_TSV:
+		for _, s := range listed {
+			fields := []string{s.Id, s.Snippet, s.Description, strings.Join(s.Tags, ","), s.Name}
+			for i, field := range fields {
+				fields[i] = tsvEscaper.Replace(field)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !strings.Contains(format, "{{") {
+		return fmt.Errorf("%s is unsupported format, use %s, %s, %s or a template such as '{{.Id}} {{.Snippet}}'", format, FORMAT_JSON, FORMAT_JSONL, FORMAT_TSV)
+	}
+	t, err := template.New("list").Funcs(template.FuncMap{"join": strings.Join}).Parse(format)
+	if err != nil {
+		return err
+	}
+	for _, s := range listed {
+		if err := t.Execute(w, s); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tsvEscaper replaces the characters which would break a TSV field with
+// spaces. Use JSON to keep them.
+var tsvEscaper = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
@@ -0,0 +1,63 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestList(t *testing.T) {
+	linippets := linippet.Linippets{
+		{Id: "a", Name: "logs", Snippet: "git log -n ${{count:10}} ${{ref}}", Description: "recent\tcommits", Tags: []string{"git", "log"}},
+		{Id: "b", Snippet: "ls", Source: "/team/ops.json", ReadOnly: true},
+	}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FORMAT_JSONL,
+			want: `{"id":"a","name":"logs","snippet":"git log -n ${{count:10}} ${{ref}}","description":"recent\tcommits","tags":["git","log"],"source":"","read_only":false,"args":[{"name":"count","default":"10"},{"name":"ref","default":""}]}` + "\n" +
+				`{"id":"b","name":"","snippet":"ls","description":"","tags":[],"source":"/team/ops.json","read_only":true,"args":[]}` + "\n",
+		},
+		{
+			format: FORMAT_TSV,
+			want:   "a\tgit log -n ${{count:10}} ${{ref}}\trecent commits\tgit,log\tlogs\nb\tls\t\t\t\n",
+		},
+		{
+			format: `{{.Id}} {{join .Tags ","}}{{range .Args}} {{.Name}}={{.Default}}{{end}}`,
+			want:   "a git,log count=10 ref=\nb \n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var b strings.Builder
+			if err := List(&b, linippets, tt.format); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("List =\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestListJsonEmpty(t *testing.T) {
+	var b strings.Builder
+	if err := List(&b, nil, FORMAT_JSON); err != nil {
+		t.Fatal(err)
+	}
+	if want := "[]\n"; b.String() != want {
+		t.Errorf("List = %q, want %q", b.String(), want)
+	}
+}
+
+func TestListUnsupportedFormat(t *testing.T) {
+	for _, format := range []string{"yaml", "{{.Missing}}", "{{range}"} {
+		var b strings.Builder
+		if err := List(&b, linippet.Linippets{{Id: "a", Snippet: "ls"}}, format); err == nil {
+			t.Errorf("List succeeded with %q", format)
+		}
+	}
+}
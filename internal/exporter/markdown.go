@@ -0,0 +1,147 @@
+package exporter
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+)
+
+// ways of grouping the snippets of a cheat sheet
+const (
+	GROUP_BY_TAG    = "tag"
+	GROUP_BY_SOURCE = "source"
+	GROUP_BY_NONE   = "none"
+)
+
+const (
+	untaggedGroupName = "Untagged"
+	globalGroupName   = "Your snippets"
+)
+
+//go:embed markdown.tmpl
+var markdownTemplate string
+
+// CheatSheet is the data a markdown cheat sheet template is executed with.
+type CheatSheet struct {
+	Title  string
+	Groups []CheatSheetGroup
+}
+
+// CheatSheetGroup is the snippets having a tag or coming from a snippet file.
+// Name is empty when the snippets are not grouped.
+type CheatSheetGroup struct {
+	Name     string
+	Snippets []CheatSheetSnippet
+}
+
+// CheatSheetSnippet is a linippet with the arguments of its placeholders.
+type CheatSheetSnippet struct {
+	linippet.Linippet
+	Args []snippet.Arg
+}
+
+// NewCheatSheet groups linippets by groupBy. Grouped by tag, a linippet is in
+// the group of each of its tags, sorted by name, and linippets without tags
+// come last. Grouped by source, groups are in reading order. When every
+// linippet falls in the same group, they are not grouped.
+func NewCheatSheet(title string, linippets linippet.Linippets, groupBy string) (*CheatSheet, error) {
+	var groupNames func(l linippet.Linippet) []string
+	switch groupBy {
+	case GROUP_BY_TAG:
+		groupNames = func(l linippet.Linippet) []string {
+			if len(l.Tags) == 0 {
+				return []string{untaggedGroupName}
+			}
+			return l.Tags
+		}
+	case GROUP_BY_SOURCE:
+		groupNames = func(l linippet.Linippet) []string {
+			if label := linippet.SourceLabel(l.Source); label != "" {
+				return []string{label}
+			}
+			return []string{globalGroupName}
+		}
+	case GROUP_BY_NONE:
+		groupNames = func(l linippet.Linippet) []string {
+			return []string{""}
+		}
+	default:
+		return nil, fmt.Errorf("%s is unsupported grouping, use %s, %s or %s", groupBy, GROUP_BY_TAG, GROUP_BY_SOURCE, GROUP_BY_NONE)
+	}
+
+	var groups []CheatSheetGroup
+	groupIndex := make(map[string]int)
+	for _, l := range linippets {
+		s := CheatSheetSnippet{Linippet: l, Args: snippet.UniqueArgs(l.Snippet)}
+		for _, name := range groupNames(l) {
+			index, ok := groupIndex[strings.ToLower(name)]
+			if !ok {
+				index = len(groups)
+				groupIndex[strings.ToLower(name)] = index
+				groups = append(groups, CheatSheetGroup{Name: name})
+			}
+			groups[index].Snippets = append(groups[index].Snippets, s)
+		}
+	}
+	if groupBy == GROUP_BY_TAG {
+		slices.SortStableFunc(groups, func(a, b CheatSheetGroup) int {
+			if (a.Name == untaggedGroupName) != (b.Name == untaggedGroupName) {
+				if a.Name == untaggedGroupName {
+					return 1
+				}
+				return -1
+			}
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	}
+	if len(groups) == 1 {
+		groups[0].Name = ""
+	}
+	return &CheatSheet{Title: title, Groups: groups}, nil
+}
+
+// Markdown renders sheet with the text/template tmpl, or with the default
+// layout when tmpl is empty. Templates can use the functions "code", which
+// returns a text as inline code, "fence", which returns a text as a fenced
+// code block, and "cell", which escapes a text for a table cell.
+func Markdown(w io.Writer, sheet *CheatSheet, tmpl string) error {
+	if tmpl == "" {
+		tmpl = markdownTemplate
+	}
+	t, err := template.New("markdown").Funcs(template.FuncMap{
+		"code":  markdownCode,
+		"fence": markdownFence,
+		"cell":  markdownCell,
+	}).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed parse template: %w", err)
+	}
+	return t.Execute(w, sheet)
+}
+
+// markdownCode returns text as inline code, delimited by more backticks than
+// it contains.
+func markdownCode(text string) string {
+	delimiter := strings.Repeat("`", snippet.LongestRun(text, '`')+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return delimiter + " " + text + " " + delimiter
+	}
+	return delimiter + text + delimiter
+}
+
+// markdownFence returns text as a fenced shell code block.
+func markdownFence(text string) string {
+	fence := strings.Repeat("`", max(3, snippet.LongestRun(text, '`')+1))
+	return fence + "sh\n" + text + "\n" + fence
+}
+
+// markdownCell escapes the pipes and newlines of text, which end a table cell.
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(text)
+}
@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func groupNames(sheet *CheatSheet) []string {
+	names := make([]string, len(sheet.Groups))
+	for i, group := range sheet.Groups {
+		names[i] = group.Name
+		for _, s := range group.Snippets {
+			names[i] += " " + s.Id
+		}
+	}
+	return names
+}
+
+func TestNewCheatSheet(t *testing.T) {
+	linippets := linippet.Linippets{
+		{Id: "a", Snippet: "git status", Tags: []string{"git"}},
+		{Id: "b", Snippet: "ls"},
+		{Id: "c", Snippet: "docker ps", Tags: []string{"Docker", "ops"}, Source: "/team/ops.json"},
+		{Id: "d", Snippet: "git push", Tags: []string{"ops", "git"}, Source: "/team/ops.json"},
+	}
+	tests := []struct {
+		groupBy string
+		want    []string
+	}{
+		{groupBy: GROUP_BY_TAG, want: []string{"Docker c", "git a d", "ops c d", "Untagged b"}},
+		{groupBy: GROUP_BY_SOURCE, want: []string{"Your snippets a b", "/team/ops.json c d"}},
+		{groupBy: GROUP_BY_NONE, want: []string{" a b c d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			sheet, err := NewCheatSheet("Snippets", linippets, tt.groupBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := groupNames(sheet); !slices.Equal(got, tt.want) {
+				t.Errorf("groups = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// a single group is not named
+	sheet, err := NewCheatSheet("Snippets", linippets[1:2], GROUP_BY_TAG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := groupNames(sheet); !slices.Equal(got, []string{" b"}) {
+		t.Errorf("groups = %q", got)
+	}
+	if _, err := NewCheatSheet("Snippets", linippets, "author"); err == nil {
+		t.Error("NewCheatSheet succeeded with an unsupported grouping")
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	sheet, err := NewCheatSheet("Team", linippet.Linippets{
+		{Id: "a", Snippet: "git log -n ${{count:10}} ${{ref}} ${{count}}", Description: "Recent commits", Tags: []string{"git"}},
+		{Id: "b", Snippet: "echo `date` | tr a-z A-Z", Tags: []string{"shell"}},
+	}, GROUP_BY_TAG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := Markdown(&b, sheet, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := "# Team\n" +
+		"\n## git\n" +
+		"\n### Recent commits\n" +
+		"\n```sh\ngit log -n ${{count:10}} ${{ref}} ${{count}}\n```\n" +
+		"\n| Argument | Default |\n| --- | --- |\n| `count` | `10` |\n| `ref` |  |\n" +
+		"\n## shell\n" +
+		"\n### ``echo `date` | tr a-z A-Z``\n" +
+		"\n```sh\necho `date` | tr a-z A-Z\n```\n"
+	if b.String() != want {
+		t.Errorf("Markdown =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestMarkdownTemplate(t *testing.T) {
+	sheet, err := NewCheatSheet("Team", linippet.Linippets{
+		{Id: "a", Snippet: "git log -n ${{count:10}}", Tags: []string{"git"}},
+	}, GROUP_BY_NONE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := `{{range .Groups}}{{range .Snippets}}{{.Id}}: {{code .Snippet}}{{range .Args}} {{.Name}}={{.Default}}{{end}}{{end}}{{end}}`
+	var b strings.Builder
+	if err := Markdown(&b, sheet, tmpl); err != nil {
+		t.Fatal(err)
+	}
+	if want := "a: `git log -n ${{count:10}}` count=10"; b.String() != want {
+		t.Errorf("Markdown = %q, want %q", b.String(), want)
+	}
+	if err := Markdown(&b, sheet, "{{range}"); err == nil {
+		t.Error("Markdown succeeded with a broken template")
+	}
+}
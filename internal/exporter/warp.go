@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"bytes"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+	"gopkg.in/yaml.v3"
+)
+
+const WARP_EXT = ".yaml"
+
+// warpWorkflow is a Warp workflow.
+type warpWorkflow struct {
+	Name        string         `yaml:"name"`
+	Command     string         `yaml:"command"`
+	Tags        []string       `yaml:"tags,omitempty"`
+	Description string         `yaml:"description,omitempty"`
+	Arguments   []warpArgument `yaml:"arguments,omitempty"`
+}
+
+type warpArgument struct {
+	Name         string  `yaml:"name"`
+	DefaultValue *string `yaml:"default_value"`
+}
+
+// WarpName returns the name of the Warp workflow of l: its description, or
+// its Warp command when it has none.
+func WarpName(l linippet.Linippet) string {
+	if l.Description != "" {
+		return l.Description
+	}
+	return warpCommand(l.Snippet)
+}
+
+// warpCommand returns s with its placeholders ${{arg:default}} converted to
+// the Warp arguments {{arg}}.
+func warpCommand(s string) string {
+	return snippet.ExtractArgsRegexp.ReplaceAllString(s, "{{$1}}")
+}
+
+// Warp returns the Warp workflow of l. Its placeholders ${{arg:default}} are
+// converted to {{arg}}, with the first default of each argument.
+func Warp(l linippet.Linippet) ([]byte, error) {
+	workflow := warpWorkflow{
+		Name:        WarpName(l),
+		Command:     warpCommand(l.Snippet),
+		Tags:        l.Tags,
+		Description: l.Description,
+	}
+	for _, arg := range snippet.UniqueArgs(l.Snippet) {
+		argument := warpArgument{Name: arg.Name}
+		if arg.Default != "" {
+			argument.DefaultValue = &arg.Default
+		}
+		workflow.Arguments = append(workflow.Arguments, argument)
+	}
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(workflow); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,79 @@
+package exporter
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/importer"
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestWarp(t *testing.T) {
+	out, err := Warp(linippet.Linippet{
+		Snippet:     "git log -n ${{count:10}} ${{branch}} -- ${{count}} ${{branch:main}}",
+		Description: "Recent commits",
+		Tags:        []string{"git"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `name: Recent commits
+command: git log -n {{count}} {{branch}} -- {{count}} {{branch}}
+tags:
+  - git
+description: Recent commits
+arguments:
+  - name: count
+    default_value: "10"
+  - name: branch
+    default_value: main
+`
+	if string(out) != want {
+		t.Errorf("Warp =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestWarpRoundTrip(t *testing.T) {
+	linippets := linippet.Linippets{
+		{Snippet: "docker run --rm -it ${{image:alpine}} ${{cmd:sh}}", Description: "Run a container", Tags: []string{"docker"}},
+		{Snippet: "ls -la"},
+		{Snippet: "git log -n ${{count:10}}"},
+	}
+	for _, l := range linippets {
+		out, err := Warp(l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := importer.WarpWorkflow("workflow.yaml", bytes.NewReader(out))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Linippets) != 1 {
+			t.Fatalf("linippets = %+v", result.Linippets)
+		}
+		got := result.Linippets[0]
+		if got.Snippet != l.Snippet || got.Description != l.Description || !slices.Equal(got.Tags, l.Tags) {
+			t.Errorf("round trip = %+v, want %+v", got, l)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	used := make(map[string]bool)
+	got := []string{
+		FileName("Recent commits!", ".yaml", used),
+		FileName("recent  commits", ".yaml", used),
+		FileName("日本語", ".yaml", used),
+		FileName("a very long name which goes on and on and on until it is too long to be a file name", ".yaml", used),
+	}
+	want := []string{
+		"recent_commits.yaml",
+		"recent_commits_2.yaml",
+		"snippet.yaml",
+		"a_very_long_name_which_goes_on_and_on_and_on_until_it_is_too.yaml",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("FileName = %q, want %q", got, want)
+	}
+}
@@ -26,6 +26,12 @@ const (
 	bonusSimilarityMax = 30  // max similarity length bonus
 )
 
+// field weights, in percent of the score a match in that field earns
+const (
+	weightSnippet     = 100
+	weightDescription = 60
+)
+
 // charClass categorizes characters for boundary bonus calculation.
 type charClass int
 
@@ -229,11 +235,17 @@ func calculateScore(query, snippet string, matches []int) int {
 }
 
 type SearchResult struct {
-	Linippet linippet.Linippet
-	Matches  []int
-	Score    int
+	Linippet           linippet.Linippet
+	Matches            []int // byte indices in Linippet.Snippet
+	DescriptionMatches []int // byte indices in Linippet.Description
+	Score              int
+	// NameMatch is set when the query is the Name of the linippet.
+	NameMatch bool
 }
 
+// FuzzySearch returns the linippets matching every whitespace separated term of
+// query, best first. A linippet whose Name is the query comes before the
+// others, whether or not its snippet matches.
 func FuzzySearch(ctx context.Context, query string, linippets linippet.Linippets) []SearchResult {
 	// split query by whitespace
 	queries := strings.Fields(query)
@@ -246,26 +258,51 @@ func FuzzySearch(ctx context.Context, query string, linippets linippet.Linippets
 		if ctx.Err() != nil {
 			return nil
 		}
+		nameMatch := linippet.Name != "" && len(queries) == 1 && queries[0] == linippet.Name
 		allMatched := true
 		allMatches := make([]int, 0)
+		allDescriptionMatches := make([]int, 0)
 		totalScore := 0
 		for _, q := range queries {
 			// TODO: use cache
 			matches, score := fuzzyMatch(q, linippet.Snippet)
-			if matches == nil {
+			descriptionMatches, descriptionScore := fuzzyMatch(q, linippet.Description)
+			score = score * weightSnippet / 100
+			descriptionScore = descriptionScore * weightDescription / 100
+			// each query term counts toward the field it matches best
+			if matches != nil && (descriptionMatches == nil || score >= descriptionScore) {
+				allMatches = append(allMatches, matches...)
+				totalScore += score
+			} else if descriptionMatches != nil {
+				allDescriptionMatches = append(allDescriptionMatches, descriptionMatches...)
+				totalScore += descriptionScore
+			} else {
 				allMatched = false
 				break
 			}
-			allMatches = append(allMatches, matches...)
-			totalScore += score
 		}
 		if allMatched {
-			results = append(results, SearchResult{Linippet: linippet, Matches: allMatches, Score: totalScore})
+			results = append(results, SearchResult{
+				Linippet:           linippet,
+				Matches:            allMatches,
+				DescriptionMatches: allDescriptionMatches,
+				Score:              totalScore,
+				NameMatch:          nameMatch,
+			})
+		} else if nameMatch {
+			results = append(results, SearchResult{Linippet: linippet, NameMatch: true})
 		}
 	}
 
-	// sort desc by score, then asc by snippet length as tiebreaker
+	// exact name first, then sort desc by score, then asc by snippet length as
+	// tiebreaker
 	slices.SortFunc(results, func(a, b SearchResult) int {
+		if a.NameMatch != b.NameMatch {
+			if a.NameMatch {
+				return -1
+			}
+			return 1
+		}
 		if a.Score != b.Score {
 			return b.Score - a.Score
 		}
@@ -273,3 +310,34 @@ func FuzzySearch(ctx context.Context, query string, linippets linippet.Linippets
 	})
 	return results
 }
+
+// ParseQuery splits query into the text to fuzzy match and the tags given as
+// "#tag" tokens.
+func ParseQuery(query string) (string, []string) {
+	var text []string
+	var tags []string
+	for _, field := range strings.Fields(query) {
+		if len(field) > 1 && strings.HasPrefix(field, "#") {
+			tags = append(tags, field[1:])
+			continue
+		}
+		text = append(text, field)
+	}
+	return strings.Join(text, " "), tags
+}
+
+// Search restricts linippets to those having every "#tag" in query, then
+// fuzzy matches them against the rest of query. When query holds only tags,
+// or is blank, the restricted linippets are returned in their original order.
+func Search(ctx context.Context, query string, linippets linippet.Linippets) []SearchResult {
+	text, tags := ParseQuery(query)
+	candidates := linippets.FilterByTags(tags)
+	if text != "" {
+		return FuzzySearch(ctx, text, candidates)
+	}
+	results := make([]SearchResult, 0, len(candidates))
+	for _, l := range candidates {
+		results = append(results, SearchResult{Linippet: l})
+	}
+	return results
+}
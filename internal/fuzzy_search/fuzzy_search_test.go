@@ -496,3 +496,153 @@ func TestFuzzySearchEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestFuzzySearchDescription(t *testing.T) {
+	t.Run("query matching only the description finds the snippet", func(t *testing.T) {
+		linippets := linippet.Linippets{
+			{Id: "id-1", Snippet: "kubectl rollout restart deployment ${{name}}", Description: "restart pods"},
+			{Id: "id-2", Snippet: "ls -la"},
+		}
+		results := FuzzySearch(context.Background(), "pods", linippets)
+		if len(results) != 1 || results[0].Linippet.Id != "id-1" {
+			t.Fatalf("expected only id-1, got %+v", results)
+		}
+		if len(results[0].Matches) != 0 {
+			t.Errorf("expected no snippet matches, got %v", results[0].Matches)
+		}
+		if want := []int{8, 9, 10, 11}; !slices.Equal(results[0].DescriptionMatches, want) {
+			t.Errorf("DescriptionMatches = %v, want %v", results[0].DescriptionMatches, want)
+		}
+	})
+
+	t.Run("terms may match different fields", func(t *testing.T) {
+		linippets := linippet.Linippets{
+			{Id: "id-1", Snippet: "kubectl rollout restart deployment ${{name}}", Description: "restart pods"},
+		}
+		results := FuzzySearch(context.Background(), "kubectl pods", linippets)
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		if len(results[0].Matches) == 0 || len(results[0].DescriptionMatches) == 0 {
+			t.Errorf("expected matches in both fields, got %+v", results[0])
+		}
+	})
+
+	t.Run("snippet match outweighs the same description match", func(t *testing.T) {
+		linippets := linippet.Linippets{
+			{Id: "described", Snippet: "echo hello", Description: "docker"},
+			{Id: "snippet", Snippet: "docker", Description: "echo hello"},
+		}
+		results := FuzzySearch(context.Background(), "docker", linippets)
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].Linippet.Id != "snippet" || results[0].Score <= results[1].Score {
+			t.Errorf("expected snippet match to rank first, got %q (scores: %d vs %d)",
+				results[0].Linippet.Id, results[0].Score, results[1].Score)
+		}
+	})
+}
+
+func TestFuzzySearchName(t *testing.T) {
+	t.Run("exact name ranks first", func(t *testing.T) {
+		linippets := linippet.Linippets{
+			{Id: "exact", Snippet: "docker compose logs -f", Name: "dlogs"},
+			{Id: "fuzzy", Snippet: "dlogs"},
+		}
+		results := FuzzySearch(context.Background(), "dlogs", linippets)
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].Linippet.Id != "exact" || !results[0].NameMatch || results[1].NameMatch {
+			t.Errorf("expected the named snippet first, got %+v", results)
+		}
+	})
+
+	t.Run("exact name is found without matching the snippet", func(t *testing.T) {
+		linippets := linippet.Linippets{
+			{Id: "named", Snippet: "kubectl get pods", Name: "pods2"},
+			{Id: "other", Snippet: "ls"},
+		}
+		results := FuzzySearch(context.Background(), "pods2", linippets)
+		if len(results) != 1 || results[0].Linippet.Id != "named" {
+			t.Fatalf("expected only named, got %+v", results)
+		}
+		if len(results[0].Matches) != 0 {
+			t.Errorf("expected no snippet matches, got %v", results[0].Matches)
+		}
+	})
+
+	t.Run("name prefix is not an exact match", func(t *testing.T) {
+		linippets := linippet.Linippets{
+			{Id: "named", Snippet: "kubectl get pods", Name: "kgpods"},
+		}
+		if results := FuzzySearch(context.Background(), "kgp", linippets); len(results) != 1 || results[0].NameMatch {
+			t.Errorf("expected a fuzzy match only, got %+v", results)
+		}
+	})
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantText string
+		wantTags []string
+	}{
+		{name: "text only", query: "git br", wantText: "git br", wantTags: nil},
+		{name: "tags only", query: "#docker #k8s", wantText: "", wantTags: []string{"docker", "k8s"}},
+		{name: "mixed", query: "#k8s kubectl  del", wantText: "kubectl del", wantTags: []string{"k8s"}},
+		{name: "bare hash is text", query: "# echo", wantText: "# echo", wantTags: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, tags := ParseQuery(tt.query)
+			if text != tt.wantText || !slices.Equal(tags, tt.wantTags) {
+				t.Errorf("ParseQuery(%q) = (%q, %v), want (%q, %v)", tt.query, text, tags, tt.wantText, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestSearchWithTags(t *testing.T) {
+	linippets := linippet.Linippets{
+		{Id: "docker-ps", Snippet: "docker ps -a", Tags: []string{"docker"}},
+		{Id: "kubectl-pods", Snippet: "kubectl get pods", Tags: []string{"k8s"}},
+		{Id: "docker-pods", Snippet: "docker ps --filter name=pod", Tags: []string{"Docker", "k8s"}},
+	}
+	ids := func(results []SearchResult) []string {
+		got := make([]string, len(results))
+		for i, r := range results {
+			got[i] = r.Linippet.Id
+		}
+		return got
+	}
+
+	t.Run("tags only keeps original order", func(t *testing.T) {
+		got := ids(Search(context.Background(), "#docker", linippets))
+		if want := []string{"docker-ps", "docker-pods"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("tags restrict before fuzzy matching", func(t *testing.T) {
+		got := ids(Search(context.Background(), "#k8s docker", linippets))
+		if want := []string{"docker-pods"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("blank query keeps everything", func(t *testing.T) {
+		got := ids(Search(context.Background(), "  ", linippets))
+		if want := []string{"docker-ps", "kubectl-pods", "docker-pods"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown tag matches nothing", func(t *testing.T) {
+		if got := Search(context.Background(), "#psql", linippets); len(got) != 0 {
+			t.Errorf("expected no results, got %v", ids(got))
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// zshExtendedRegexp matches the ": <start>:<elapsed>;" prefix of zsh
+	// extended history.
+	zshExtendedRegexp = regexp.MustCompile(`^: *\d+:\d+;`)
+	// bashTimestampRegexp matches the timestamp lines bash writes when
+	// HISTTIMEFORMAT is set.
+	bashTimestampRegexp = regexp.MustCompile(`^#\d+$`)
+)
+
+// zshMeta is the byte zsh escapes special bytes of its history with.
+const zshMeta = 0x83
+
+// HistoryEntry is a command of a shell history with the number of times it was
+// run.
+type HistoryEntry struct {
+	Command string
+	Count   int
+}
+
+// IsZshHistory reports whether the history file named name holding data is
+// written by zsh.
+func IsZshHistory(name string, data []byte) bool {
+	return strings.Contains(filepath.Base(name), "zsh") || zshExtendedRegexp.Match(data)
+}
+
+// History returns the commands of a bash or zsh history read from r, oldest
+// first. The zsh format, with or without extended history, joins lines ending
+// with "\" with the next one. The bash format skips timestamp lines.
+func History(r io.Reader, zsh bool) ([]string, error) {
+	var commands []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var continued []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !zsh {
+			if !bashTimestampRegexp.MatchString(line) {
+				commands = append(commands, line)
+			}
+			continue
+		}
+		line = unmetafy(line)
+		if len(continued) == 0 {
+			line = zshExtendedRegexp.ReplaceAllString(line, "")
+		}
+		if strings.HasSuffix(line, "\\") {
+			continued = append(continued, strings.TrimSuffix(line, "\\"))
+			continue
+		}
+		commands = append(commands, strings.Join(append(continued, line), "\n"))
+		continued = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed read history: %w", err)
+	}
+	if len(continued) > 0 {
+		commands = append(commands, strings.Join(continued, "\n"))
+	}
+	return commands, nil
+}
+
+// unmetafy restores the bytes zsh escapes in its history file.
+func unmetafy(line string) string {
+	if strings.IndexByte(line, zshMeta) == -1 {
+		return line
+	}
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == zshMeta && i+1 < len(line) {
+			i++
+			b.WriteByte(line[i] ^ 0x20)
+			continue
+		}
+		b.WriteByte(line[i])
+	}
+	return b.String()
+}
+
+// historyRun is when a command was last run: the number of commands run
+// after it in its history, and the index of the history.
+type historyRun struct {
+	age     int
+	history int
+}
+
+// RankHistory counts the commands of histories, oldest first each, and
+// returns them by frequency, the most recently run first among the same
+// frequency. As histories of different shells do not share a clock, recency
+// is measured within each history: the last command of every history is
+// as recent, and ties are broken by the order of histories. Blank and
+// multi-line commands are left out, as linippet supports only one-liners.
+func RankHistory(histories ...[]string) []HistoryEntry {
+	counts := make(map[string]int)
+	lastRun := make(map[string]historyRun)
+	for h, commands := range histories {
+		for i, command := range commands {
+			command = strings.TrimSpace(command)
+			if command == "" || strings.ContainsAny(command, "\n\r") {
+				continue
+			}
+			counts[command]++
+			run := historyRun{age: len(commands) - 1 - i, history: h}
+			if last, ok := lastRun[command]; !ok || run.age < last.age {
+				lastRun[command] = run
+			}
+		}
+	}
+	entries := make([]HistoryEntry, 0, len(counts))
+	for command, count := range counts {
+		entries = append(entries, HistoryEntry{Command: command, Count: count})
+	}
+	slices.SortFunc(entries, func(a, b HistoryEntry) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		runA, runB := lastRun[a.Command], lastRun[b.Command]
+		if runA.age != runB.age {
+			return runA.age - runB.age
+		}
+		return runA.history - runB.history
+	})
+	return entries
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		zsh   bool
+		want  []string
+	}{
+		{
+			name:  "bash",
+			input: "ls -la\n#1700000000\ngit status\n",
+			want:  []string{"ls -la", "git status"},
+		},
+		{
+			name:  "zsh extended",
+			input: ": 1700000000:0;git status\n: 1700000001:3;for f in *; do\\\necho $f\\\ndone\n:  1700000002:0;echo a\\\\\n",
+			zsh:   true,
+			want:  []string{"git status", "for f in *; do\necho $f\ndone", "echo a\\"},
+		},
+		{
+			name:  "zsh plain",
+			input: "ls\necho \x83\xa3\n",
+			zsh:   true,
+			want:  []string{"ls", "echo \x83"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := History(strings.NewReader(tt.input), tt.zsh)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("History = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsZshHistory(t *testing.T) {
+	if !IsZshHistory("/home/me/.zsh_history", []byte("ls\n")) {
+		t.Error("a file named after zsh is not zsh history")
+	}
+	if !IsZshHistory("/home/me/.histfile", []byte(": 1700000000:0;ls\n")) {
+		t.Error("extended history is not zsh history")
+	}
+	if IsZshHistory("/home/me/.bash_history", []byte("#1700000000\nls\n")) {
+		t.Error("bash history is zsh history")
+	}
+}
+
+func TestRankHistory(t *testing.T) {
+	entries := RankHistory([]string{"ls", "git status", "  ", "make test", "ls ", "git status", "a\nb", "make test", "pwd"})
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Command)
+	}
+	// ties are broken by the last run, most recent first
+	if want := []string{"make test", "git status", "ls", "pwd"}; !slices.Equal(got, want) {
+		t.Errorf("RankHistory = %q, want %q", got, want)
+	}
+	if entries[0].Count != 2 || entries[3].Count != 1 {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestRankHistories(t *testing.T) {
+	entries := RankHistory(
+		[]string{"git status", "pwd", "ls"},
+		[]string{"make", "pwd", "docker ps", "top"},
+	)
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Command)
+	}
+	// recency is measured from the end of each history, not by their order
+	if want := []string{"pwd", "ls", "top", "docker ps", "git status", "make"}; !slices.Equal(got, want) {
+		t.Errorf("RankHistory = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+// Package importer converts the snippets of other tools into linippets.
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+// Result is the linippets converted from a source, with warnings about what
+// could not be converted as it is.
+type Result struct {
+	Linippets linippet.Linippets
+	Warnings  []string
+}
+
+func (r *Result) warnf(format string, a ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, a...))
+}
+
+var nonWordRegexp = regexp.MustCompile(`\W+`)
+
+// argName returns name with the characters other than letters, digits and
+// underscores replaced, as placeholder names are made of them.
+func argName(name string) string {
+	name = strings.Trim(nonWordRegexp.ReplaceAllString(name, "_"), "_")
+	if name == "" {
+		return "arg"
+	}
+	return name
+}
+
+// placeholder returns the linippet placeholder of an argument. A default
+// value containing "}" is dropped with a warning, as the placeholder syntax
+// cannot hold it.
+func (r *Result) placeholder(location, name, defaultValue string) string {
+	name = argName(name)
+	if strings.Contains(defaultValue, "}") {
+		r.warnf("%s: default value %q of %s contains \"}\" and is dropped", location, defaultValue, name)
+		defaultValue = ""
+	}
+	if defaultValue == "" {
+		return "${{" + name + "}}"
+	}
+	return "${{" + name + ":" + defaultValue + "}}"
+}
+
+// tags returns the linippet tags of a list of tags. A tag containing spaces
+// is split with a warning, as linippet tags are separated by them.
+func (r *Result) tags(location string, tags []string) []string {
+	for _, tag := range tags {
+		if strings.ContainsAny(strings.TrimSpace(tag), " \t") {
+			r.warnf("%s: tag %q is split into %q", location, tag, linippet.ParseTags(tag))
+		}
+	}
+	return linippet.ParseTags(strings.Join(tags, ","))
+}
+
+// openingRegexp matches the end of a line which the next one continues
+// without a ";", such as "do", "then", "{" or a pipe.
+var openingRegexp = regexp.MustCompile(`(?:^|[\s;])(?:do|then|else)$|[{(|&;]$`)
+
+// oneLiner returns the command written in lines as one line, as linippet
+// supports only one-liners. A line ending with "\" continues on the next one,
+// as does a line opening a block or a pipe, and other lines are joined with
+// "; " with a warning.
+func (r *Result) oneLiner(location string, lines []string) string {
+	var b strings.Builder
+	joined := false
+	for i, line := range lines {
+		if i > 0 {
+			line = strings.TrimLeft(line, " \t")
+		}
+		if i == len(lines)-1 {
+			b.WriteString(line)
+			break
+		}
+		if continued, ok := strings.CutSuffix(line, "\\"); ok {
+			b.WriteString(strings.TrimRight(continued, " \t") + " ")
+			continue
+		}
+		if openingRegexp.MatchString(line) {
+			b.WriteString(line + " ")
+			continue
+		}
+		b.WriteString(line + "; ")
+		joined = true
+	}
+	if joined {
+		r.warnf("%s: the lines of the command are joined with \"; \"", location)
+	}
+	return b.String()
+}
+
+// SplitDuplicates separates the linippets whose snippet is already in
+// existing, or earlier in linippets, from the fresh ones.
+func SplitDuplicates(linippets, existing linippet.Linippets) (fresh, duplicates linippet.Linippets) {
+	seen := make(map[string]bool, len(existing)+len(linippets))
+	for _, l := range existing {
+		seen[strings.TrimSpace(l.Snippet)] = true
+	}
+	for _, l := range linippets {
+		key := strings.TrimSpace(l.Snippet)
+		if seen[key] {
+			duplicates = append(duplicates, l)
+			continue
+		}
+		seen[key] = true
+		fresh = append(fresh, l)
+	}
+	return fresh, duplicates
+}
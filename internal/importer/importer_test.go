@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+func TestSplitDuplicates(t *testing.T) {
+	existing := linippet.Linippets{{Id: "a", Snippet: "ls -la"}}
+	imported := linippet.Linippets{
+		{Snippet: "ls -la "},
+		{Snippet: "pwd"},
+		{Snippet: "pwd", Description: "again"},
+		{Snippet: "git status"},
+	}
+	fresh, duplicates := SplitDuplicates(imported, existing)
+	snippets := func(linippets linippet.Linippets) []string {
+		result := make([]string, len(linippets))
+		for i, l := range linippets {
+			result[i] = l.Snippet
+		}
+		return result
+	}
+	if got, want := snippets(fresh), []string{"pwd", "git status"}; !slices.Equal(got, want) {
+		t.Errorf("fresh = %q, want %q", got, want)
+	}
+	if got, want := snippets(duplicates), []string{"ls -la ", "pwd"}; !slices.Equal(got, want) {
+		t.Errorf("duplicates = %q, want %q", got, want)
+	}
+}
+
+func TestOneLiner(t *testing.T) {
+	tests := []struct {
+		lines      []string
+		want       string
+		wantWarned bool
+	}{
+		{lines: []string{"ls"}, want: "ls"},
+		{lines: []string{"docker run \\", "  --rm alpine"}, want: "docker run --rm alpine"},
+		{lines: []string{"cd /tmp", "ls"}, want: "cd /tmp; ls", wantWarned: true},
+		{lines: []string{"if true; then", "  echo yes", "fi"}, want: "if true; then echo yes; fi", wantWarned: true},
+		{lines: []string{"ps aux |", "  grep go"}, want: "ps aux | grep go"},
+		{lines: []string{"echo undo", "ls"}, want: "echo undo; ls", wantWarned: true},
+	}
+	for _, tt := range tests {
+		result := &Result{}
+		if got := result.oneLiner("test", tt.lines); got != tt.want {
+			t.Errorf("oneLiner(%q) = %q, want %q", tt.lines, got, tt.want)
+		}
+		if warned := len(result.Warnings) > 0; warned != tt.wantWarned {
+			t.Errorf("oneLiner(%q) warnings = %q", tt.lines, result.Warnings)
+		}
+	}
+}
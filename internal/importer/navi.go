@@ -0,0 +1,163 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"github.com/muleyuck/linippet/internal/snippet"
+)
+
+const NAVI_EXT = ".cheat"
+
+var (
+	// naviVarRegexp matches <var>. A space right after "<" is not a variable
+	// but a redirection.
+	naviVarRegexp = regexp.MustCompile(`<(\w[\w-]*)>`)
+	// naviGeneratorRegexp matches "$ var: command".
+	naviGeneratorRegexp = regexp.MustCompile(`^\$\s*(\w[\w-]*)\s*:\s*(.*)$`)
+)
+
+// Navi converts the snippets of every navi cheat file in fsys, walked in
+// lexical order.
+func Navi(fsys fs.FS) (*Result, error) {
+	result := &Result{}
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || path.Ext(name) != NAVI_EXT {
+			return nil
+		}
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return result.readNaviCheat(name, file)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// NaviCheat converts the snippets of the navi cheat file named name read from
+// r.
+func NaviCheat(name string, r io.Reader) (*Result, error) {
+	result := &Result{}
+	if err := result.readNaviCheat(name, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// naviSection is the snippets and variable generators following a "%" line.
+type naviSection struct {
+	tags       []string
+	linippets  linippet.Linippets
+	generators map[string]string
+}
+
+// readNaviCheat reads a cheat file: "%" starts a section with its tags, "#"
+// describes the next command, ";" is a comment and "$ var: command" is a
+// generator of a variable, used by the commands of its section. Consecutive
+// command lines form one command.
+func (r *Result) readNaviCheat(name string, reader io.Reader) error {
+	section := &naviSection{generators: make(map[string]string)}
+	var description string
+	var command []string
+	commandLine := 0
+	flushCommand := func() {
+		if len(command) == 0 {
+			return
+		}
+		location := fmt.Sprintf("%s:%d", name, commandLine)
+		section.linippets = append(section.linippets, linippet.Linippet{
+			Snippet:     r.convertNaviVars(location, r.oneLiner(location, command)),
+			Description: description,
+			Tags:        section.tags,
+		})
+		description, command = "", nil
+	}
+	flushSection := func() {
+		flushCommand()
+		r.addNaviSection(name, section)
+		section = &naviSection{generators: make(map[string]string)}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flushCommand()
+		case strings.HasPrefix(trimmed, "%"):
+			flushSection()
+			section.tags = linippet.ParseTags(strings.TrimPrefix(trimmed, "%"))
+		case strings.HasPrefix(trimmed, "#"):
+			flushCommand()
+			description = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+		case strings.HasPrefix(trimmed, ";"), strings.HasPrefix(trimmed, "```"):
+		case strings.HasPrefix(trimmed, "@"):
+			flushCommand()
+			r.warnf("%s:%d: %s is not supported and is ignored", name, lineNumber, trimmed)
+		case strings.HasPrefix(trimmed, "$"):
+			flushCommand()
+			match := naviGeneratorRegexp.FindStringSubmatch(trimmed)
+			if match == nil {
+				r.warnf("%s:%d: %s is not a variable definition and is ignored", name, lineNumber, trimmed)
+				continue
+			}
+			section.generators[argName(match[1])] = match[2]
+		default:
+			if len(command) == 0 {
+				commandLine = lineNumber
+			}
+			command = append(command, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed read %s: %w", name, err)
+	}
+	flushSection()
+	return nil
+}
+
+// convertNaviVars converts the variables in command into placeholders.
+func (r *Result) convertNaviVars(location, command string) string {
+	return naviVarRegexp.ReplaceAllStringFunc(command, func(variable string) string {
+		return r.placeholder(location, naviVarRegexp.FindStringSubmatch(variable)[1], "")
+	})
+}
+
+// addNaviSection adds the linippets of section, keeping the generators of the
+// variables each uses as metadata.
+func (r *Result) addNaviSection(name string, section *naviSection) {
+	warned := make(map[string]bool)
+	for _, l := range section.linippets {
+		for _, arg := range snippet.ExtractSnippetArgsWithDefaults(l.Snippet) {
+			generator, ok := section.generators[arg.Name]
+			if !ok {
+				continue
+			}
+			if l.Generators == nil {
+				l.Generators = make(map[string]string)
+			}
+			l.Generators[arg.Name] = generator
+			if !warned[arg.Name] {
+				warned[arg.Name] = true
+				r.warnf("%s: linippet does not run `%s` to suggest values of %s; it is kept as metadata", name, generator, arg.Name)
+			}
+		}
+		r.Linippets = append(r.Linippets, l)
+	}
+}
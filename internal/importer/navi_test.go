@@ -0,0 +1,96 @@
+package importer
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNaviCheat(t *testing.T) {
+	input := `% git, code
+
+# Change branch
+git checkout <branch>
+
+; a comment
+# Show a file at a commit
+git show <commit-id>:<file> \
+  | less
+
+$ branch: git branch | awk '{print $NF}'
+$ commit-id: git log --format=%h --- --column 1
+
+% docker
+@ git
+
+# Run and remove
+docker run --rm <image>
+docker ps -a
+echo "keep  spaces" > out.txt
+`
+	result, err := NaviCheat("git.cheat", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		snippet     string
+		description string
+		tags        []string
+		generators  map[string]string
+	}{
+		{
+			snippet:     "git checkout ${{branch}}",
+			description: "Change branch",
+			tags:        []string{"git", "code"},
+			generators:  map[string]string{"branch": "git branch | awk '{print $NF}'"},
+		},
+		{
+			snippet:     "git show ${{commit_id}}:${{file}} | less",
+			description: "Show a file at a commit",
+			tags:        []string{"git", "code"},
+			generators:  map[string]string{"commit_id": "git log --format=%h --- --column 1"},
+		},
+		{
+			snippet:     `docker run --rm ${{image}}; docker ps -a; echo "keep  spaces" > out.txt`,
+			description: "Run and remove",
+			tags:        []string{"docker"},
+		},
+	}
+	if len(result.Linippets) != len(want) {
+		t.Fatalf("linippets = %+v, want %d", result.Linippets, len(want))
+	}
+	for i, w := range want {
+		got := result.Linippets[i]
+		if got.Snippet != w.snippet || got.Description != w.description ||
+			!slices.Equal(got.Tags, w.tags) || !maps.Equal(got.Generators, w.generators) {
+			t.Errorf("linippets[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+	// two generators, the ignored "@" line and the joined lines
+	if len(result.Warnings) != 4 {
+		t.Errorf("warnings = %q, want 4", result.Warnings)
+	}
+}
+
+func TestNavi(t *testing.T) {
+	fsys := fstest.MapFS{
+		"b.cheat":          {Data: []byte("% b\n\nls -b\n")},
+		"sub/a.cheat":      {Data: []byte("% a\n\nls -a\n")},
+		"README.md":        {Data: []byte("# not a cheat\n\nls\n")},
+		"sub/notes.txt":    {Data: []byte("ls -n\n")},
+		"sub/deep/c.cheat": {Data: []byte("ls -c\n")},
+	}
+	result, err := Navi(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(result.Linippets))
+	for i, l := range result.Linippets {
+		got[i] = l.Snippet
+	}
+	if want := []string{"ls -b", "ls -a", "ls -c"}; !slices.Equal(got, want) {
+		t.Errorf("snippets = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/muleyuck/linippet/internal/linippet"
+)
+
+// petSnippets is the content of pet's snippet.toml.
+type petSnippets struct {
+	Snippets []struct {
+		Description string   `toml:"description"`
+		Command     string   `toml:"command"`
+		Tag         []string `toml:"tag"`
+	} `toml:"snippets"`
+}
+
+var (
+	// petParamRegexp matches <param> and <param=default>. As in pet, a space
+	// right after "<" or before ">" is not a parameter but a redirection.
+	petParamRegexp = regexp.MustCompile(`<([^\s<>=](?:[^<>=]*[^\s<>=])?)(?:=((?:[^<>]*[^\s<>])?))?>`)
+	// petChoicesRegexp matches the choices of <param=|_a_||_b_|>.
+	petChoicesRegexp = regexp.MustCompile(`\|_(.*?)_\|`)
+)
+
+// Pet converts the snippets of pet's snippet.toml read from r.
+func Pet(r io.Reader) (*Result, error) {
+	var content petSnippets
+	if _, err := toml.NewDecoder(r).Decode(&content); err != nil {
+		return nil, fmt.Errorf("failed parse pet snippets: %w", err)
+	}
+	result := &Result{}
+	for i, s := range content.Snippets {
+		location := fmt.Sprintf("snippet %d", i+1)
+		var lines []string
+		for line := range strings.SplitSeq(s.Command, "\n") {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, strings.TrimRight(line, " \t\r"))
+			}
+		}
+		if len(lines) == 0 {
+			result.warnf("%s: command is empty and is skipped", location)
+			continue
+		}
+		result.Linippets = append(result.Linippets, linippet.Linippet{
+			Snippet:     result.convertPetParams(location, result.oneLiner(location, lines)),
+			Description: s.Description,
+			Tags:        result.tags(location, s.Tag),
+		})
+	}
+	return result, nil
+}
+
+// convertPetParams converts the pet parameters in command into linippet
+// placeholders. Only the first choice of a multiple choice parameter is kept,
+// as its default.
+func (r *Result) convertPetParams(location, command string) string {
+	return petParamRegexp.ReplaceAllStringFunc(command, func(param string) string {
+		match := petParamRegexp.FindStringSubmatch(param)
+		name, defaultValue := match[1], match[2]
+		if choices := petChoicesRegexp.FindAllStringSubmatch(defaultValue, -1); len(choices) > 0 {
+			if len(choices) > 1 {
+				r.warnf("%s: only the first choice of %s is kept as its default", location, param)
+			}
+			defaultValue = choices[0][1]
+		}
+		return r.placeholder(location, name, defaultValue)
+	})
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPet(t *testing.T) {
+	input := `
+[[snippets]]
+  description = "ping"
+  command = "ping <host=8.8.8.8> -c <count>"
+  tag = ["network", "#google"]
+  output = ""
+
+[[snippets]]
+  command = "cat < in.txt > <out file=out.txt>"
+  tag = ["file io"]
+
+[[snippets]]
+  command = "git checkout <branch=|_main_||_dev_|> && echo <x=a}b>"
+
+[[snippets]]
+  command = "sort <in >out"
+
+[[snippets]]
+  command = """
+for f in <dir=.>/*; do
+  echo $f
+done
+"""
+
+[[snippets]]
+  description = "empty"
+  command = "  "
+`
+	result, err := Pet(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ping ${{host:8.8.8.8}} -c ${{count}}",
+		"cat < in.txt > ${{out_file:out.txt}}",
+		"git checkout ${{branch:main}} && echo ${{x}}",
+		"sort <in >out",
+		"for f in ${{dir:.}}/*; do echo $f; done",
+	}
+	got := make([]string, len(result.Linippets))
+	for i, l := range result.Linippets {
+		got[i] = l.Snippet
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("snippets = %q, want %q", got, want)
+	}
+	if first := result.Linippets[0]; first.Description != "ping" || !slices.Equal(first.Tags, []string{"network", "google"}) {
+		t.Errorf("first = %+v", first)
+	}
+	if second := result.Linippets[1]; !slices.Equal(second.Tags, []string{"file", "io"}) {
+		t.Errorf("second = %+v", second)
+	}
+	// the split tag, the dropped default, the dropped choice, the joined lines
+	// and the empty command
+	if len(result.Warnings) != 5 {
+		t.Errorf("warnings = %q, want 5", result.Warnings)
+	}
+}
+
+func TestPetInvalidToml(t *testing.T) {
+	if _, err := Pet(strings.NewReader("[[snippets]\n")); err == nil {
+		t.Error("Pet succeeded with invalid TOML")
+	}
+}
@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/linippet"
+	"gopkg.in/yaml.v3"
+)
+
+// warpWorkflow is a Warp workflow. Fields linippet has no use for, such as
+// source_url and shells, are ignored.
+type warpWorkflow struct {
+	Name        string   `yaml:"name"`
+	Command     string   `yaml:"command"`
+	Tags        []string `yaml:"tags"`
+	Description string   `yaml:"description"`
+	Arguments   []struct {
+		Name         string  `yaml:"name"`
+		DefaultValue *string `yaml:"default_value"`
+	} `yaml:"arguments"`
+}
+
+// warpArgRegexp matches {{arg}}, and ${{arg}} to leave it as it is.
+var warpArgRegexp = regexp.MustCompile(`\$?{{\s*([\w-]+)\s*}}`)
+
+// Warp converts every Warp workflow file (*.yaml, *.yml) in fsys, walked in
+// lexical order.
+func Warp(fsys fs.FS) (*Result, error) {
+	result := &Result{}
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (path.Ext(name) != ".yaml" && path.Ext(name) != ".yml") {
+			return nil
+		}
+		file, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return result.readWarpWorkflows(name, file)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WarpWorkflow converts the Warp workflows of the file named name read from r.
+func WarpWorkflow(name string, r io.Reader) (*Result, error) {
+	result := &Result{}
+	if err := result.readWarpWorkflows(name, r); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// readWarpWorkflows reads every YAML document of a workflow file. The name of
+// a workflow is its description when it has none, unless it is the command,
+// and its arguments {{arg}} are converted to ${{arg:default}}.
+func (r *Result) readWarpWorkflows(name string, reader io.Reader) error {
+	decoder := yaml.NewDecoder(reader)
+	for index := 1; ; index++ {
+		var workflow warpWorkflow
+		err := decoder.Decode(&workflow)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed parse %s: %w", name, err)
+		}
+		location := name
+		if index > 1 {
+			location = fmt.Sprintf("%s: workflow %d", name, index)
+		}
+		var lines []string
+		for line := range strings.SplitSeq(workflow.Command, "\n") {
+			if strings.TrimSpace(line) != "" {
+				lines = append(lines, strings.TrimRight(line, " \t\r"))
+			}
+		}
+		if len(lines) == 0 {
+			r.warnf("%s: command is empty and is skipped", location)
+			continue
+		}
+		// a workflow named after its command, as exported without a
+		// description, has none
+		description := workflow.Description
+		if description == "" && workflow.Name != strings.TrimSpace(workflow.Command) {
+			description = workflow.Name
+		}
+		r.Linippets = append(r.Linippets, linippet.Linippet{
+			Snippet:     r.convertWarpArgs(location, &workflow, r.oneLiner(location, lines)),
+			Description: description,
+			Tags:        r.tags(location, workflow.Tags),
+		})
+	}
+}
+
+// convertWarpArgs converts the arguments in command into placeholders having
+// the default values of the workflow.
+func (r *Result) convertWarpArgs(location string, workflow *warpWorkflow, command string) string {
+	defaults := make(map[string]string, len(workflow.Arguments))
+	for _, arg := range workflow.Arguments {
+		if arg.DefaultValue != nil {
+			defaults[arg.Name] = *arg.DefaultValue
+		}
+	}
+	return warpArgRegexp.ReplaceAllStringFunc(command, func(arg string) string {
+		if strings.HasPrefix(arg, "$") {
+			return arg
+		}
+		name := warpArgRegexp.FindStringSubmatch(arg)[1]
+		return r.placeholder(location, name, defaults[name])
+	})
+}
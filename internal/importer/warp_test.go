@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWarpWorkflow(t *testing.T) {
+	input := `---
+name: Remove a package
+command: |-
+  brew tap beeftornado/rmtree
+  brew rmtree {{package-name}} --limit {{ limit }}{{suffix}} ${{kept}}
+tags:
+  - homebrew
+arguments:
+  - name: package-name
+    description: The name of the package
+    default_value: ~
+  - name: limit
+    default_value: 5
+shells: []
+---
+name: Empty
+command: ""
+---
+name: List files
+command: ls {{dir}}
+description: List the files of a directory
+arguments:
+  - name: dir
+    default_value: "."
+`
+	result, err := WarpWorkflow("brew.yaml", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Linippets) != 2 {
+		t.Fatalf("linippets = %+v, want 2", result.Linippets)
+	}
+	first := result.Linippets[0]
+	if want := "brew tap beeftornado/rmtree; brew rmtree ${{package_name}} --limit ${{limit:5}}${{suffix}} ${{kept}}"; first.Snippet != want {
+		t.Errorf("Snippet = %q, want %q", first.Snippet, want)
+	}
+	if first.Description != "Remove a package" || !slices.Equal(first.Tags, []string{"homebrew"}) {
+		t.Errorf("first = %+v", first)
+	}
+	if second := result.Linippets[1]; second.Snippet != "ls ${{dir:.}}" || second.Description != "List the files of a directory" {
+		t.Errorf("second = %+v", second)
+	}
+	// the joined lines and the empty command
+	if len(result.Warnings) != 2 {
+		t.Errorf("warnings = %q, want 2", result.Warnings)
+	}
+}
+
+func TestWarp(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.yaml":       {Data: []byte("name: a\ncommand: echo a\n")},
+		"sub/b.yml":    {Data: []byte("name: b\ncommand: echo b\n")},
+		"sub/c.json":   {Data: []byte(`{"name": "c"}`)},
+		"broken.txt":   {Data: []byte("name: [")},
+		"z/README.yml": {Data: []byte("name: z\ncommand: echo z\n")},
+	}
+	result, err := Warp(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(result.Linippets))
+	for i, l := range result.Linippets {
+		got[i] = l.Snippet
+	}
+	if want := []string{"echo a", "echo b", "echo z"}; !slices.Equal(got, want) {
+		t.Errorf("snippets = %q, want %q", got, want)
+	}
+
+	if _, err := Warp(fstest.MapFS{"broken.yaml": {Data: []byte("name: [")}}); err == nil {
+		t.Error("Warp succeeded with a broken workflow")
+	}
+}
@@ -0,0 +1,252 @@
+package linippet
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DirStore keeps linippets in a directory, one markdown file per linippet, so
+// that the directory can be versioned with git without merge conflicts
+// between unrelated changes. Files are named after the Ids of their linippets,
+// prefixed with a number keeping the order in which linippets are added, as
+// in a snippet file.
+type DirStore struct {
+	dir      string
+	readOnly bool
+}
+
+// NewDirStore returns the store of the directory at dir. The directory is
+// created on the first change when it does not exist. A read-only store
+// refuses every change with ErrReadOnly.
+func NewDirStore(dir string, readOnly bool) *DirStore {
+	return &DirStore{dir: dir, readOnly: readOnly}
+}
+
+// List returns the linippets of the directory. A missing directory has none.
+func (s *DirStore) List() (Linippets, error) {
+	linippets, err := readDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range linippets {
+		linippets[i].ReadOnly = s.readOnly
+	}
+	return linippets, nil
+}
+
+func (s *DirStore) Get(id string) (Linippet, error) {
+	linippets, err := s.List()
+	if err != nil {
+		return Linippet{}, err
+	}
+	targetIndex := slices.IndexFunc(linippets, func(l Linippet) bool {
+		return id == l.Id
+	})
+	if targetIndex == -1 {
+		return Linippet{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return linippets[targetIndex], nil
+}
+
+func (s *DirStore) Add(l Linippet) (Linippet, error) {
+	l.Id = uuid.NewString()
+	l.ReadOnly = false
+	err := s.modify(func(linippets Linippets) error {
+		order := 1
+		for _, existing := range linippets {
+			if n, ok := fileOrder(existing.Source); ok && n >= order {
+				order = n + 1
+			}
+		}
+		l.Source = filepath.Join(s.dir, markdownFileName(order, l.Id))
+		return writeMarkdown(l.Source, l)
+	})
+	if err != nil {
+		return Linippet{}, err
+	}
+	return l, nil
+}
+
+func (s *DirStore) Update(l Linippet) error {
+	return s.modify(func(linippets Linippets) error {
+		targetIndex := slices.IndexFunc(linippets, func(target Linippet) bool {
+			return l.Id == target.Id
+		})
+		if targetIndex == -1 {
+			return fmt.Errorf("%w: %s", ErrNotFound, l.Id)
+		}
+		l.Source = linippets[targetIndex].Source
+		l.ReadOnly = false
+		return writeMarkdown(l.Source, l)
+	})
+}
+
+func (s *DirStore) Remove(id string) error {
+	return s.modify(func(linippets Linippets) error {
+		targetIndex := slices.IndexFunc(linippets, func(l Linippet) bool {
+			return id == l.Id
+		})
+		if targetIndex == -1 {
+			return fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		if err := os.Remove(linippets[targetIndex].Source); err != nil {
+			return err
+		}
+		return syncDir(s.dir)
+	})
+}
+
+// modify calls change with the linippets of the directory, holding the lock
+// throughout. Nothing is changed while a file of the directory cannot be read.
+func (s *DirStore) modify(change func(linippets Linippets) error) (err error) {
+	if s.readOnly {
+		return fmt.Errorf("%w: %s", ErrReadOnly, s.dir)
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	// lockDir locks the directory containing the path given
+	unlock, err := lockDir(filepath.Join(s.dir, MARKDOWN_EXT))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferErr := unlock()
+		if deferErr != nil && err == nil {
+			err = deferErr
+		}
+	}()
+	linippets, err := readDir(s.dir)
+	if err != nil {
+		return err
+	}
+	return change(linippets)
+}
+
+// Watch polls the names, modification times and sizes of the files of the
+// directory.
+func (s *DirStore) Watch(ctx context.Context) (<-chan struct{}, error) {
+	return pollChanges(ctx, func() string { return statDir(s.dir) }), nil
+}
+
+// readDir reads every markdown snippet file in dir, in the order of
+// markdownPaths.
+func readDir(dir string) (Linippets, error) {
+	paths, err := markdownPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	linippets := make(Linippets, 0, len(paths))
+	for _, path := range paths {
+		l, err := readMarkdown(path)
+		if err != nil {
+			return nil, err
+		}
+		linippets = append(linippets, l)
+	}
+	return linippets, nil
+}
+
+// markdownPaths returns the markdown snippet files in dir sorted by the
+// number prefixed to their name, followed by the files without one, such as
+// files written by hand, sorted by name. A missing directory has none.
+func markdownPaths(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed read snippet directory: %w", err)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != MARKDOWN_EXT || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	// os.ReadDir sorts by name already
+	slices.SortStableFunc(paths, func(a, b string) int {
+		orderA, okA := fileOrder(a)
+		orderB, okB := fileOrder(b)
+		switch {
+		case okA && okB:
+			return cmp.Compare(orderA, orderB)
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return 0
+	})
+	return paths, nil
+}
+
+// orderRegexp matches the number prefixed to the name of a markdown snippet
+// file.
+var orderRegexp = regexp.MustCompile(`^(\d+)-`)
+
+// fileOrder returns the number prefixed to the file name of path, if any.
+func fileOrder(path string) (int, bool) {
+	match := orderRegexp.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return 0, false
+	}
+	order, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return order, true
+}
+
+// markdownFileName returns the name of the markdown snippet file for id, the
+// order-th linippet of its directory. Characters unsafe in file names are
+// replaced, as the id is kept in the front matter anyway.
+func markdownFileName(order int, id string) string {
+	base := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < ' ' {
+			return '-'
+		}
+		return r
+	}, id)
+	return fmt.Sprintf("%04d-%s%s", order, base, MARKDOWN_EXT)
+}
+
+func readMarkdown(path string) (Linippet, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Linippet{}, fmt.Errorf("failed read snippet file: %w", err)
+	}
+	return decodeMarkdown(path, b)
+}
+
+func writeMarkdown(path string, l Linippet) error {
+	out, err := encodeMarkdown(l)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, out)
+}
+
+// statDir returns a text which changes whenever a markdown snippet file in dir
+// is added, removed or modified.
+func statDir(dir string) string {
+	paths, _ := markdownPaths(dir)
+	var b strings.Builder
+	for _, path := range paths {
+		stamp := statFile(path)
+		b.WriteString(path + "\x00" + strconv.FormatInt(stamp.modTime.UnixNano(), 10) + "\x00" + strconv.FormatInt(stamp.size, 10) + "\n")
+	}
+	return b.String()
+}
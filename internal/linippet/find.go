@@ -0,0 +1,75 @@
+package linippet
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrAmbiguous = errors.New("snippet reference is ambiguous")
+
+// nameRegexp matches the names a linippet can have: letters, digits and
+// "_.-", not starting with "-" so that names are not taken for flags.
+var nameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.][A-Za-z0-9_.-]*$`)
+
+// Find returns the linippet of store referred to by ref: its Id, its Name, or
+// a prefix of a single Id, as git accepts abbreviated commit hashes. It
+// returns ErrNotFound when nothing matches and ErrAmbiguous, listing the
+// candidates, when several Ids start with ref.
+func Find(store Store, ref string) (Linippet, error) {
+	if ref == "" {
+		return Linippet{}, fmt.Errorf("%w: empty reference", ErrNotFound)
+	}
+	linippets, err := store.List()
+	if err != nil {
+		return Linippet{}, err
+	}
+	for _, l := range linippets {
+		if l.Id == ref {
+			return l, nil
+		}
+	}
+	var candidates Linippets
+	for _, l := range linippets {
+		if l.Name == ref {
+			return l, nil
+		}
+		if strings.HasPrefix(l.Id, ref) {
+			candidates = append(candidates, l)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return Linippet{}, fmt.Errorf("%w: %s", ErrNotFound, ref)
+	case 1:
+		return candidates[0], nil
+	}
+	lines := make([]string, len(candidates))
+	for i, l := range candidates {
+		lines[i] = fmt.Sprintf("  %s  %s", l.Id, l.Snippet)
+	}
+	return Linippet{}, fmt.Errorf("%w: %s matches %d snippets\n%s",
+		ErrAmbiguous, ref, len(candidates), strings.Join(lines, "\n"))
+}
+
+// CheckName checks that the Name of l, if any, is a valid name which no other
+// linippet of store has.
+func CheckName(store Store, l Linippet) error {
+	if l.Name == "" {
+		return nil
+	}
+	if !nameRegexp.MatchString(l.Name) {
+		return fmt.Errorf("name %q is invalid, use letters, digits and _.- not starting with -", l.Name)
+	}
+	linippets, err := store.List()
+	if err != nil {
+		return err
+	}
+	for _, other := range linippets {
+		if other.Name == l.Name && other.Id != l.Id {
+			return fmt.Errorf("name %s is already used by snippet %s: %s", l.Name, other.Id, other.Snippet)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package linippet
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	store := NewMemoryStore(
+		Linippet{Id: "3f2a1b", Snippet: "echo a"},
+		Linippet{Id: "3f2a9c", Snippet: "echo b"},
+		Linippet{Id: "3f", Snippet: "echo c"},
+		Linippet{Id: "7d00", Snippet: "echo d"},
+		Linippet{Id: "9e00", Name: "3f2a", Snippet: "echo e"},
+		Linippet{Id: "9e01", Name: "7d00", Snippet: "echo f"},
+	)
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr error
+	}{
+		{name: "full id", ref: "3f2a1b", want: "echo a"},
+		{name: "unique prefix", ref: "3f2a9", want: "echo b"},
+		{name: "exact id wins over prefix", ref: "3f", want: "echo c"},
+		{name: "single character prefix", ref: "7", want: "echo d"},
+		{name: "name wins over prefix", ref: "3f2a", want: "echo e"},
+		{name: "id wins over name", ref: "7d00", want: "echo d"},
+		{name: "ambiguous prefix", ref: "3f2", wantErr: ErrAmbiguous},
+		{name: "unknown", ref: "ffff", wantErr: ErrNotFound},
+		{name: "empty", ref: "", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Find(store, tt.ref)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Find(%q) error = %v, want %v", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Snippet != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.ref, got.Snippet, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	store := NewMemoryStore(
+		Linippet{Id: "a", Name: "logs", Snippet: "git log"},
+		Linippet{Id: "b", Snippet: "ls"},
+	)
+	tests := []struct {
+		name     string
+		linippet Linippet
+		wantErr  bool
+	}{
+		{name: "no name", linippet: Linippet{Id: "b"}},
+		{name: "unused name", linippet: Linippet{Id: "b", Name: "list_files.v2"}},
+		{name: "own name", linippet: Linippet{Id: "a", Name: "logs"}},
+		{name: "name of another", linippet: Linippet{Id: "b", Name: "logs"}, wantErr: true},
+		{name: "new linippet taking a name", linippet: Linippet{Name: "logs"}, wantErr: true},
+		{name: "space", linippet: Linippet{Id: "b", Name: "list files"}, wantErr: true},
+		{name: "leading dash", linippet: Linippet{Id: "b", Name: "-l"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckName(store, tt.linippet); (err != nil) != tt.wantErr {
+				t.Errorf("CheckName(%+v) error = %v, wantErr %v", tt.linippet, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package linippet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// watchInterval is how often a JsonStore checks its file for changes.
+const watchInterval = 500 * time.Millisecond
+
+// JsonStore keeps linippets in one JSON snippet file. Changes are made under
+// an advisory lock and written atomically, so several processes may share a
+// file.
+type JsonStore struct {
+	path     string
+	readOnly bool
+}
+
+// NewJsonStore returns the store of the snippet file at path. The file is
+// created on the first change when it does not exist. A read-only store
+// refuses every change with ErrReadOnly.
+func NewJsonStore(path string, readOnly bool) *JsonStore {
+	return &JsonStore{path: path, readOnly: readOnly}
+}
+
+// List returns the linippets of the file. A missing file has none.
+func (s *JsonStore) List() (Linippets, error) {
+	linippets, err := readJson(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Linippets{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range linippets {
+		linippets[i].ReadOnly = s.readOnly
+	}
+	return linippets, nil
+}
+
+func (s *JsonStore) Get(id string) (Linippet, error) {
+	linippets, err := s.List()
+	if err != nil {
+		return Linippet{}, err
+	}
+	targetIndex := slices.IndexFunc(linippets, func(l Linippet) bool {
+		return id == l.Id
+	})
+	if targetIndex == -1 {
+		return Linippet{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return linippets[targetIndex], nil
+}
+
+func (s *JsonStore) Add(l Linippet) (Linippet, error) {
+	l.Id = uuid.NewString()
+	l.Source = s.path
+	l.ReadOnly = false
+	err := s.modify(func(content *storeFile) error {
+		content.Snippets = append(content.Snippets, l)
+		return nil
+	})
+	if err != nil {
+		return Linippet{}, err
+	}
+	return l, nil
+}
+
+func (s *JsonStore) Update(l Linippet) error {
+	return s.modify(func(content *storeFile) error {
+		targetIndex := slices.IndexFunc(content.Snippets, func(target Linippet) bool {
+			return l.Id == target.Id
+		})
+		if targetIndex == -1 {
+			return fmt.Errorf("%w: %s", ErrNotFound, l.Id)
+		}
+		l.Source = s.path
+		l.ReadOnly = false
+		content.Snippets[targetIndex] = l
+		return nil
+	})
+}
+
+func (s *JsonStore) Remove(id string) error {
+	return s.modify(func(content *storeFile) error {
+		targetIndex := slices.IndexFunc(content.Snippets, func(l Linippet) bool {
+			return id == l.Id
+		})
+		if targetIndex == -1 {
+			return fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		content.Snippets = slices.Delete(content.Snippets, targetIndex, targetIndex+1)
+		return nil
+	})
+}
+
+// modify applies change to the content of the file and writes it back,
+// holding the lock throughout. A missing file is created, but a file which
+// cannot be read is never overwritten.
+func (s *JsonStore) modify(change func(content *storeFile) error) (err error) {
+	if s.readOnly {
+		return fmt.Errorf("%w: %s", ErrReadOnly, s.path)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	unlock, err := lockDir(s.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferErr := unlock()
+		if deferErr != nil && err == nil {
+			err = deferErr
+		}
+	}()
+	content, err := readStoreFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		content = &storeFile{}
+	} else if err != nil {
+		return err
+	}
+	if err := change(content); err != nil {
+		return err
+	}
+	return writeStoreFile(s.path, content)
+}
+
+// Watch polls the modification time and size of the file.
+func (s *JsonStore) Watch(ctx context.Context) (<-chan struct{}, error) {
+	return pollChanges(ctx, func() fileStamp { return statFile(s.path) }), nil
+}
+
+// pollChanges calls stamp every watchInterval and sends a value to the
+// returned channel whenever the result differs from the previous one. The
+// channel is closed once ctx is done.
+func pollChanges[T comparable](ctx context.Context, stamp func() T) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+	last := stamp()
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			current := stamp()
+			if current == last {
+				continue
+			}
+			last = current
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return changes
+}
+
+// fileStamp identifies a version of a file. It is zero for a missing file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
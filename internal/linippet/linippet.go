@@ -1,106 +1,127 @@
 package linippet
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"slices"
-
-	"github.com/google/uuid"
+	"path/filepath"
 )
 
 type Linippet struct {
-	Id      string `json:"id"`
-	Snippet string `json:"snippet"`
-	// TODO: description
+	Id string `json:"id"`
+	// Name is an optional short name, unique among the linippets, which
+	// refers to the linippet like its Id.
+	Name        string   `json:"name,omitempty"`
+	Snippet     string   `json:"snippet"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Generators maps argument names to shell commands suggesting their
+	// values, kept from imported snippets. linippet does not run them.
+	Generators map[string]string `json:"generators,omitempty"`
+	// Source is the path of the snippet file the linippet belongs to.
+	Source string `json:"-"`
+	// ReadOnly is set for linippets of the collections in LINIPPET_PATH.
+	ReadOnly bool `json:"-"`
 }
 type Linippets []Linippet
 
-func ReadLinippets() (Linippets, error) {
-	path, err := checkJsonPath()
+var (
+	ErrReadOnly = errors.New("snippet belongs to a read-only collection")
+	ErrNotFound = errors.New("snippet is not found")
+)
+
+func readJson(path string) (Linippets, error) {
+	s, err := readStoreFile(path)
 	if err != nil {
 		return nil, err
 	}
-	return readJson(path)
+	return s.Snippets, nil
 }
 
-func readJson(path string) (Linippets, error) {
+// readStoreFile reads the snippet file at path, upgrading older formats.
+func readStoreFile(path string) (*storeFile, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed read snippet file: %w", err)
 	}
-	var linippets Linippets
-	if err := json.Unmarshal(b, &linippets); err != nil {
+	s, err := decodeStoreFile(path, b)
+	if err != nil {
 		return nil, err
 	}
-	return linippets, nil
-}
-
-func AddLinippet(snippet string) error {
-	linippets, err := ReadLinippets()
-	if err != nil {
-		// create new data when reading error
-		fmt.Println(err)
-		linippets = Linippets{}
+	for i := range s.Snippets {
+		s.Snippets[i].Source = path
 	}
-	linippets = append(linippets, Linippet{
-		Id:      uuid.NewString(),
-		Snippet: snippet,
-	})
-	return writeLinippets(linippets)
+	return s, nil
 }
 
-func UpdateLinippet(id, snippet string) error {
-	linippets, err := ReadLinippets()
+// writeStoreFile replaces the snippet file at path with s.
+func writeStoreFile(path string, s *storeFile) error {
+	out, err := encodeStoreFile(s)
 	if err != nil {
 		return err
 	}
-	targetIndex := slices.IndexFunc(linippets, func(l Linippet) bool {
-		return id == l.Id
-	})
-	if targetIndex == -1 {
-		return fmt.Errorf("Linippet Id %s is no found", id)
-	}
-	linippets[targetIndex].Snippet = snippet
-	return writeLinippets(linippets)
+	return writeFileAtomic(path, out)
 }
 
-func RemoveLinippet(id string) error {
-	linippets, err := ReadLinippets()
+// resolveSymlinks returns the file a symbolic link at path points to, so that
+// snippet files kept in a dotfiles directory are written through their link.
+// path is returned as it is when it is not a link or cannot be resolved.
+func resolveSymlinks(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
 	if err != nil {
-		return err
+		return path
 	}
-	targetIndex := slices.IndexFunc(linippets, func(l Linippet) bool {
-		return id == l.Id
-	})
-	if targetIndex == -1 {
-		return fmt.Errorf("Linippet Id %s is no found", id)
-	}
-	newLinippets := slices.Delete(linippets, targetIndex, targetIndex+1)
-	return writeLinippets(newLinippets)
+	return resolved
 }
 
-func writeLinippets(linippets Linippets) (err error) {
-	path, err := checkJsonPath()
-	if err != nil {
-		return err
-	}
-	file, err := os.Create(path)
+// writeFileAtomic replaces the file at path atomically: data is written and
+// synced to a temporary file which is then renamed over path, so readers
+// never see a partially written file. A symbolic link at path is kept and
+// the file it points to is replaced.
+func writeFileAtomic(path string, data []byte) (err error) {
+	path = resolveSymlinks(path)
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("failed open file: %w", err)
 	}
 	defer func() {
-		deferErr := file.Close()
-		if deferErr != nil {
-			err = deferErr
+		// the temporary file is left only when something failed
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
 		}
 	}()
-	out, err := json.MarshalIndent(&linippets, "", "  ")
+	mode := os.FileMode(0644)
+	if info, statErr := os.Stat(path); statErr == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := file.Chmod(mode); err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed replace snippet file: %w", err)
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir flushes a directory so that a rename in it is durable.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
 	if err != nil {
 		return err
 	}
-	if _, err := file.Write(out); err != nil {
+	if err := dir.Sync(); err != nil {
+		_ = dir.Close()
 		return err
 	}
-	return nil
+	return dir.Close()
 }
@@ -0,0 +1,252 @@
+package linippet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// setupStores points the global snippet file at a temporary directory and
+// moves into a fresh repository directory. It returns the repository root.
+func setupStores(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("HOME", filepath.Join(root, "home"))
+	t.Setenv(ENV_NAME, filepath.Join(root, "data"))
+	repo := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+	return repo
+}
+
+func defaultStore(t *testing.T) Store {
+	t.Helper()
+	store, err := NewDefaultStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultStoreMergesProjectFiles(t *testing.T) {
+	repo := setupStores(t)
+	writeFile(t, filepath.Join(filepath.Dir(repo), PROJECT_FILE_NAME), `[{"id":"outside","snippet":"echo outside"}]`)
+	writeFile(t, filepath.Join(repo, PROJECT_FILE_NAME), `[{"id":"root","snippet":"echo root"}]`)
+	writeFile(t, filepath.Join(repo, "sub", PROJECT_FILE_NAME), `[{"id":"sub","snippet":"echo sub"}]`)
+	writeFile(t, getJsonPath(), `[{"id":"global","snippet":"echo global"}]`)
+	t.Chdir(filepath.Join(repo, "sub"))
+
+	linippets, err := defaultStore(t).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, len(linippets))
+	for i, l := range linippets {
+		ids[i] = l.Id
+	}
+	// The search stops at the git root, so "outside" is not merged.
+	if want := []string{"sub", "root", "global"}; !slices.Equal(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if got := SourceLabel(linippets[1].Source); got != filepath.Join("..", PROJECT_FILE_NAME) {
+		t.Errorf("SourceLabel = %q, want %q", got, filepath.Join("..", PROJECT_FILE_NAME))
+	}
+	if got := SourceLabel(linippets[2].Source); got != "" {
+		t.Errorf("SourceLabel of global = %q, want empty", got)
+	}
+}
+
+func TestCrudRoutesToSourceFile(t *testing.T) {
+	repo := setupStores(t)
+	projectPath := filepath.Join(repo, PROJECT_FILE_NAME)
+	writeFile(t, projectPath, `[{"id":"project","snippet":"echo project"}]`)
+	writeFile(t, getJsonPath(), `[{"id":"global","snippet":"echo global"}]`)
+
+	store := defaultStore(t)
+	if err := store.Update(Linippet{Id: "project", Snippet: "echo updated"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove("global"); err != nil {
+		t.Fatal(err)
+	}
+	projectLinippets, err := readJson(projectPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projectLinippets) != 1 || projectLinippets[0].Snippet != "echo updated" {
+		t.Errorf("project file = %+v", projectLinippets)
+	}
+	globalLinippets, err := readJson(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(globalLinippets) != 0 {
+		t.Errorf("global file = %+v, want empty", globalLinippets)
+	}
+}
+
+func TestProjectJsonPathDefaultsToGitRoot(t *testing.T) {
+	repo := setupStores(t)
+	if err := os.MkdirAll(filepath.Join(repo, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Join(repo, "a", "b"))
+
+	path, err := ProjectJsonPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(repo, PROJECT_FILE_NAME); path != want {
+		t.Errorf("ProjectJsonPath = %q, want %q", path, want)
+	}
+	if _, err := NewJsonStore(path, false).Add(Linippet{Snippet: "make test"}); err != nil {
+		t.Fatal(err)
+	}
+	linippets, err := defaultStore(t).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linippets) != 1 || linippets[0].Source != path {
+		t.Errorf("linippets = %+v", linippets)
+	}
+}
+
+func TestDefaultStoreCollections(t *testing.T) {
+	repo := setupStores(t)
+	team := filepath.Join(filepath.Dir(repo), "team")
+	writeFile(t, filepath.Join(team, "b.json"), `[{"id":"shared","snippet":"echo team b"},{"id":"team-b","snippet":"echo b"}]`)
+	writeFile(t, filepath.Join(team, "a.json"), `[{"id":"team-a","snippet":"echo a"}]`)
+	extra := filepath.Join(filepath.Dir(repo), "extra.json")
+	writeFile(t, extra, `[{"id":"team-a","snippet":"echo shadowed"}]`)
+	writeFile(t, getJsonPath(), `[{"id":"shared","snippet":"echo personal"}]`)
+	t.Setenv(PATH_ENV_NAME, team+string(os.PathListSeparator)+filepath.Join(repo, "missing")+string(os.PathListSeparator)+extra)
+
+	linippets, err := defaultStore(t).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]string, len(linippets))
+	for i, l := range linippets {
+		got[i] = l.Snippet
+	}
+	// personal wins over collections, and collections are read in order
+	want := []string{"echo personal", "echo a", "echo b"}
+	if !slices.Equal(got, want) {
+		t.Errorf("snippets = %v, want %v", got, want)
+	}
+	if linippets[0].ReadOnly || !linippets[1].ReadOnly || !linippets[2].ReadOnly {
+		t.Errorf("unexpected ReadOnly flags: %+v", linippets)
+	}
+
+	store := defaultStore(t)
+	if err := store.Update(Linippet{Id: "team-a", Snippet: "echo changed"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Update error = %v, want ErrReadOnly", err)
+	}
+	if err := store.Remove("team-b"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Remove error = %v, want ErrReadOnly", err)
+	}
+	if err := store.Remove("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAddKeepsBrokenFile(t *testing.T) {
+	setupStores(t)
+	broken := "[\n  {\"id\": \"a\", \"snippet\": \"echo a\"},\n  {\"id\": \"b\" \"snippet\": \"echo b\"}\n]"
+	writeFile(t, getJsonPath(), broken)
+
+	_, err := defaultStore(t).Add(Linippet{Snippet: "echo new"})
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Add error = %v, want *ParseError", err)
+	}
+	if parseErr.Line != 3 || parseErr.Column != 14 {
+		t.Errorf("error at %d:%d, want 3:14", parseErr.Line, parseErr.Column)
+	}
+	b, err := os.ReadFile(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != broken {
+		t.Errorf("broken file was modified: %s", b)
+	}
+}
+
+func TestAddWritesThroughSymlink(t *testing.T) {
+	repo := setupStores(t)
+	target := filepath.Join(filepath.Dir(repo), "dotfiles", LINIPPET_DATA_FILE_NAME)
+	writeFile(t, target, "[]")
+	if err := os.MkdirAll(filepath.Dir(getJsonPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, getJsonPath()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := defaultStore(t).Add(Linippet{Snippet: "echo new"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Lstat(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("%s is not a symbolic link anymore", getJsonPath())
+	}
+	linippets, err := readJson(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linippets) != 1 || linippets[0].Snippet != "echo new" {
+		t.Errorf("linked file = %+v", linippets)
+	}
+}
+
+func TestRepairFile(t *testing.T) {
+	setupStores(t)
+	broken := `[
+  {"id": "a", "snippet": "echo {a}"},
+  {"id": "b" "snippet": "echo b"},
+  {"snippet": "echo c", "tags": ["x"]},
+  {"id": "d", "snippet": "echo d",`
+	writeFile(t, getJsonPath(), broken)
+
+	backupPath, salvaged, err := RepairFile(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if salvaged != 2 {
+		t.Errorf("salvaged = %d, want 2", salvaged)
+	}
+	backup, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(backup) != broken {
+		t.Errorf("backup = %s, want the broken file", backup)
+	}
+	linippets, err := defaultStore(t).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linippets) != 2 || linippets[0].Snippet != "echo {a}" || linippets[1].Snippet != "echo c" {
+		t.Fatalf("linippets = %+v", linippets)
+	}
+	if linippets[1].Id == "" || !slices.Equal(linippets[1].Tags, []string{"x"}) {
+		t.Errorf("salvaged linippet = %+v", linippets[1])
+	}
+}
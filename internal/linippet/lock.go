@@ -0,0 +1,31 @@
+package linippet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// lockDir takes an exclusive advisory lock on the directory containing path
+// and returns the function releasing it. The directory is locked rather than
+// the file because writes replace the file, and rather than a lock file so
+// that project directories are not littered. The directory of the file a
+// symbolic link points to is locked, as it is the one written.
+func lockDir(path string) (unlock func() error, err error) {
+	dir, err := os.Open(filepath.Dir(resolveSymlinks(path)))
+	if err != nil {
+		return nil, fmt.Errorf("failed open directory to lock: %w", err)
+	}
+	if err := syscall.Flock(int(dir.Fd()), syscall.LOCK_EX); err != nil {
+		_ = dir.Close()
+		return nil, fmt.Errorf("failed lock %s: %w", dir.Name(), err)
+	}
+	return func() error {
+		if err := syscall.Flock(int(dir.Fd()), syscall.LOCK_UN); err != nil {
+			_ = dir.Close()
+			return err
+		}
+		return dir.Close()
+	}, nil
+}
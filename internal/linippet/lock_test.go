@@ -0,0 +1,159 @@
+package linippet
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const writerEnvName = "LINIPPET_TEST_WRITER"
+
+// assertSnippets fails unless the global snippet file holds exactly want, in
+// any order.
+func assertSnippets(t *testing.T, want []string) {
+	t.Helper()
+	linippets, err := readJson(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int)
+	for _, l := range linippets {
+		got[l.Snippet]++
+	}
+	if len(linippets) != len(want) {
+		t.Errorf("got %d snippets, want %d", len(linippets), len(want))
+	}
+	for _, snippet := range want {
+		if got[snippet] != 1 {
+			t.Errorf("snippet %q found %d times, want once", snippet, got[snippet])
+		}
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	setupStores(t)
+	store := NewJsonStore(getJsonPath(), false)
+	const writers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	want := make([]string, writers)
+	for i := range writers {
+		want[i] = fmt.Sprintf("echo %d", i)
+		wg.Go(func() {
+			_, err := store.Add(Linippet{Snippet: want[i]})
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertSnippets(t, want)
+}
+
+func TestConcurrentUpdateAndRemove(t *testing.T) {
+	setupStores(t)
+	store := NewJsonStore(getJsonPath(), false)
+	const count = 20
+	for i := range count * 2 {
+		if _, err := store.Add(Linippet{Snippet: fmt.Sprintf("echo %d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	linippets, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count*3)
+	var want []string
+	for i, l := range linippets {
+		if i < count {
+			l.Snippet = "updated " + l.Snippet
+			want = append(want, l.Snippet)
+			wg.Go(func() { errs <- store.Update(l) })
+		} else {
+			wg.Go(func() { errs <- store.Remove(l.Id) })
+		}
+	}
+	for i := range count {
+		snippet := fmt.Sprintf("added %d", i)
+		want = append(want, snippet)
+		wg.Go(func() {
+			_, err := store.Add(Linippet{Snippet: snippet})
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertSnippets(t, want)
+}
+
+// TestWriterProcess is not a test by itself: it adds snippets when run as a
+// child process by TestConcurrentWriterProcesses.
+func TestWriterProcess(t *testing.T) {
+	writer := os.Getenv(writerEnvName)
+	if writer == "" {
+		t.Skip("only run as a writer process")
+	}
+	store := NewJsonStore(getJsonPath(), false)
+	for i := range 10 {
+		if _, err := store.Add(Linippet{Snippet: fmt.Sprintf("echo %s-%d", writer, i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestConcurrentWriterProcesses(t *testing.T) {
+	if os.Getenv(writerEnvName) != "" {
+		t.Skip("already a writer process")
+	}
+	setupStores(t)
+	const processes = 8
+	var wg sync.WaitGroup
+	outputs := make([]string, processes)
+	errs := make([]error, processes)
+	var want []string
+	for p := range processes {
+		for i := range 10 {
+			want = append(want, fmt.Sprintf("echo %d-%d", p, i))
+		}
+		wg.Go(func() {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestWriterProcess$", "-test.count=1")
+			cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%d", writerEnvName, p))
+			out, err := cmd.CombinedOutput()
+			outputs[p], errs[p] = string(out), err
+		})
+	}
+	wg.Wait()
+	for p, err := range errs {
+		if err != nil {
+			t.Fatalf("writer %d failed: %v\n%s", p, err, outputs[p])
+		}
+	}
+	assertSnippets(t, want)
+
+	// no temporary file is left behind
+	entries, err := os.ReadDir(filepath.Dir(getJsonPath()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %s is left", entry.Name())
+		}
+	}
+}
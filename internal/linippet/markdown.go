@@ -0,0 +1,127 @@
+package linippet
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/muleyuck/linippet/internal/snippet"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MARKDOWN_EXT       = ".md"
+	frontMatterDivider = "---"
+	minFenceLength     = 3
+)
+
+// frontMatter is the YAML header of a markdown snippet file.
+type frontMatter struct {
+	Id          string            `yaml:"id"`
+	Name        string            `yaml:"name,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty,flow"`
+	Generators  map[string]string `yaml:"generators,omitempty"`
+}
+
+// encodeMarkdown returns the markdown snippet file of l: the front matter
+// followed by the snippet in a fenced code block. The fence is longer than any
+// backtick run in the snippet, so that the snippet is kept as it is.
+func encodeMarkdown(l Linippet) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(frontMatterDivider + "\n")
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	err := encoder.Encode(frontMatter{
+		Id:          l.Id,
+		Name:        l.Name,
+		Description: l.Description,
+		Tags:        l.Tags,
+		Generators:  l.Generators,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	fence := strings.Repeat("`", max(minFenceLength, snippet.LongestRun(l.Snippet, '`')+1))
+	b.WriteString(frontMatterDivider + "\n\n")
+	b.WriteString(fence + "sh\n")
+	b.WriteString(l.Snippet + "\n")
+	b.WriteString(fence + "\n")
+	return b.Bytes(), nil
+}
+
+// decodeMarkdown reads the markdown snippet file at path. Text around the
+// code block is ignored, and a file without an id takes its file name as one.
+func decodeMarkdown(path string, data []byte) (Linippet, error) {
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r") != frontMatterDivider {
+		return Linippet{}, &ParseError{Path: path, Line: 1, Column: 1, Err: errors.New("front matter is not found")}
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], "\r") == frontMatterDivider {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return Linippet{}, &ParseError{Path: path, Line: len(lines), Column: 1, Err: errors.New("front matter is not closed")}
+	}
+	var header frontMatter
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &header); err != nil {
+		return Linippet{}, &ParseError{Path: path, Line: 2, Column: 1, Err: err}
+	}
+
+	start := -1
+	var fence string
+	for i := end + 1; i < len(lines); i++ {
+		if fence = openingFence(lines[i]); fence != "" {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return Linippet{}, &ParseError{Path: path, Line: len(lines), Column: 1, Err: errors.New("code block is not found")}
+	}
+	for i := start + 1; i < len(lines); i++ {
+		if isClosingFence(lines[i], fence) {
+			id := header.Id
+			if id == "" {
+				id = strings.TrimSuffix(filepath.Base(path), MARKDOWN_EXT)
+			}
+			return Linippet{
+				Id:          id,
+				Name:        header.Name,
+				Snippet:     strings.Join(lines[start+1:i], "\n"),
+				Description: header.Description,
+				Tags:        header.Tags,
+				Generators:  header.Generators,
+				Source:      path,
+			}, nil
+		}
+	}
+	return Linippet{}, &ParseError{Path: path, Line: start + 1, Column: 1, Err: errors.New("code block is not closed")}
+}
+
+// openingFence returns the fence which line opens a code block with, or ""
+// when it does not.
+func openingFence(line string) string {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return ""
+	}
+	length := len(line) - len(strings.TrimLeft(line, line[:1]))
+	if length < minFenceLength {
+		return ""
+	}
+	return line[:length]
+}
+
+// isClosingFence reports whether line closes a code block opened with fence.
+func isClosingFence(line, fence string) bool {
+	line = strings.TrimRight(line, " \t\r")
+	return len(line) >= len(fence) && strings.Trim(line, fence[:1]) == ""
+}
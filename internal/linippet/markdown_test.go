@@ -0,0 +1,83 @@
+package linippet
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		linippet Linippet
+	}{
+		{name: "plain", linippet: Linippet{Id: "a", Snippet: "ls -la"}},
+		{name: "description and tags", linippet: Linippet{Id: "b", Snippet: "git log ${{n:10}}", Description: "Show: \"log\"", Tags: []string{"git", "log"}}},
+		{name: "backticks", linippet: Linippet{Id: "c", Snippet: "echo ```x``` `date`"}},
+		{name: "multiline", linippet: Linippet{Id: "d", Snippet: "for f in *; do\n  echo $f\ndone"}},
+		{name: "trailing newline", linippet: Linippet{Id: "e", Snippet: "echo e\n"}},
+		{name: "front matter divider", linippet: Linippet{Id: "f", Snippet: "---", Description: "---"}},
+		{name: "empty", linippet: Linippet{Id: "g"}},
+		{name: "name", linippet: Linippet{Id: "i", Name: "recent-commits", Snippet: "git log"}},
+		{name: "generators", linippet: Linippet{Id: "h", Snippet: "git checkout ${{branch}}", Generators: map[string]string{"branch": "git branch | cut -c3-"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := encodeMarkdown(tt.linippet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := decodeMarkdown("snippets/"+tt.linippet.Id+MARKDOWN_EXT, data)
+			if err != nil {
+				t.Fatalf("decodeMarkdown(%s) error = %v", data, err)
+			}
+			if got.Id != tt.linippet.Id || got.Name != tt.linippet.Name || got.Snippet != tt.linippet.Snippet ||
+				got.Description != tt.linippet.Description || !slices.Equal(got.Tags, tt.linippet.Tags) ||
+				!maps.Equal(got.Generators, tt.linippet.Generators) {
+				t.Errorf("decoded = %+v, want %+v\n%s", got, tt.linippet, data)
+			}
+		})
+	}
+}
+
+func TestDecodeMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     Linippet
+		wantLine int
+	}{
+		{
+			name: "handwritten",
+			data: "---\ndescription: Disk usage\ntags:\n  - fs\n---\n\n# du\n\nSorted by size.\n\n~~~bash\ndu -sh * | sort -h\n~~~\n\nMore notes.\n",
+			want: Linippet{Id: "du", Snippet: "du -sh * | sort -h", Description: "Disk usage", Tags: []string{"fs"}},
+		},
+		{name: "no front matter", data: "```\nls\n```\n", wantLine: 1},
+		{name: "front matter not closed", data: "---\nid: a\n", wantLine: 3},
+		{name: "no code block", data: "---\nid: a\n---\nls\n", wantLine: 5},
+		{name: "code block not closed", data: "---\nid: a\n---\n````\nls\n```\n", wantLine: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeMarkdown("snippets/du.md", []byte(tt.data))
+			if tt.wantLine != 0 {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("error = %v, want *ParseError", err)
+				}
+				if parseErr.Line != tt.wantLine {
+					t.Errorf("error at line %d, want %d", parseErr.Line, tt.wantLine)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Id != tt.want.Id || got.Snippet != tt.want.Snippet ||
+				got.Description != tt.want.Description || !slices.Equal(got.Tags, tt.want.Tags) {
+				t.Errorf("decoded = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
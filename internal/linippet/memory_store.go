@@ -0,0 +1,107 @@
+package linippet
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore keeps linippets in memory, for tests and as a reference for
+// other stores.
+type MemoryStore struct {
+	mu        sync.Mutex
+	linippets Linippets
+	watchers  map[chan struct{}]bool
+}
+
+// NewMemoryStore returns a store holding linippets as they are.
+func NewMemoryStore(linippets ...Linippet) *MemoryStore {
+	return &MemoryStore{
+		linippets: slices.Clone(linippets),
+		watchers:  make(map[chan struct{}]bool),
+	}
+}
+
+func (s *MemoryStore) List() (Linippets, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.linippets), nil
+}
+
+func (s *MemoryStore) Get(id string) (Linippet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targetIndex := s.index(id)
+	if targetIndex == -1 {
+		return Linippet{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return s.linippets[targetIndex], nil
+}
+
+func (s *MemoryStore) Add(l Linippet) (Linippet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l.Id = uuid.NewString()
+	s.linippets = append(s.linippets, l)
+	s.notify()
+	return l, nil
+}
+
+func (s *MemoryStore) Update(l Linippet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targetIndex := s.index(l.Id)
+	if targetIndex == -1 {
+		return fmt.Errorf("%w: %s", ErrNotFound, l.Id)
+	}
+	s.linippets[targetIndex] = l
+	s.notify()
+	return nil
+}
+
+func (s *MemoryStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	targetIndex := s.index(id)
+	if targetIndex == -1 {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	s.linippets = slices.Delete(s.linippets, targetIndex, targetIndex+1)
+	s.notify()
+	return nil
+}
+
+func (s *MemoryStore) Watch(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.watchers[changes] = true
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.watchers, changes)
+		s.mu.Unlock()
+		close(changes)
+	}()
+	return changes, nil
+}
+
+// index returns the index of the linippet with id, or -1. s.mu must be held.
+func (s *MemoryStore) index(id string) int {
+	return slices.IndexFunc(s.linippets, func(l Linippet) bool {
+		return id == l.Id
+	})
+}
+
+// notify tells every watcher about a change. s.mu must be held.
+func (s *MemoryStore) notify() {
+	for changes := range s.watchers {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
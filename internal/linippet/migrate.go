@@ -0,0 +1,78 @@
+package linippet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// GlobalStorePath returns where the global store of kind, STORE_KIND_JSON or
+// STORE_KIND_DIR, is kept.
+func GlobalStorePath(kind string) (string, error) {
+	switch kind {
+	case STORE_KIND_JSON:
+		return getJsonPath(), nil
+	case STORE_KIND_DIR:
+		return getDirPath(), nil
+	}
+	return "", fmt.Errorf("%s is an unsupported store kind, use %s or %s", kind, STORE_KIND_JSON, STORE_KIND_DIR)
+}
+
+// Migrate copies every linippet of the store at from to a new store at to,
+// keeping their order, Ids, descriptions and tags. A path is a snippet
+// directory when it is an existing directory or does not end with ".json".
+// The store at to must not have any linippets yet; from is left as it is. It
+// returns the number of linippets copied.
+func Migrate(from, to string) (int, error) {
+	var linippets Linippets
+	var err error
+	if isDirPath(from) {
+		if _, statErr := os.Stat(from); statErr != nil {
+			return 0, statErr
+		}
+		linippets, err = readDir(from)
+	} else {
+		linippets, err = readJson(from)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if isDirPath(to) {
+		err = writeDir(to, linippets)
+	} else {
+		err = NewJsonStore(to, false).modify(func(content *storeFile) error {
+			if len(content.Snippets) > 0 {
+				return fmt.Errorf("%s already has snippets", to)
+			}
+			content.Snippets = linippets
+			return nil
+		})
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(linippets), nil
+}
+
+func isDirPath(path string) bool {
+	if info, err := os.Stat(path); err == nil {
+		return info.IsDir()
+	}
+	return filepath.Ext(path) != ".json"
+}
+
+// writeDir writes linippets to the snippet directory dir, which must not have
+// any snippet file yet, keeping their order.
+func writeDir(dir string, linippets Linippets) error {
+	return NewDirStore(dir, false).modify(func(existing Linippets) error {
+		if len(existing) > 0 {
+			return fmt.Errorf("%s already has snippets", dir)
+		}
+		for i, l := range linippets {
+			if err := writeMarkdown(filepath.Join(dir, markdownFileName(i+1, l.Id)), l); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,166 @@
+package linippet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParseError is returned when a snippet file cannot be parsed. Line and
+// Column locate the problem, both starting at 1.
+type ParseError struct {
+	Path   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	hint := "run `linippet doctor --repair` to salvage it"
+	if filepath.Ext(e.Path) == MARKDOWN_EXT {
+		hint = "fix or remove the file"
+	}
+	return fmt.Sprintf("snippet file %s is broken at line %d, column %d: %v (%s)",
+		e.Path, e.Line, e.Column, e.Err, hint)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError locates err, returned by decoding data, in data.
+func newParseError(path string, data []byte, err error) *ParseError {
+	offset := int64(len(data))
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	} else if errors.As(err, &typeErr) {
+		offset = typeErr.Offset
+	}
+	// the offset counts the offending byte itself
+	offset = min(max(offset-1, 0), int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return &ParseError{Path: path, Line: line, Column: column, Err: err}
+}
+
+// FileStatus is the result of checking one snippet file or directory.
+type FileStatus struct {
+	Path     string
+	ReadOnly bool
+	// Repairable is set for JSON snippet files RepairFile can salvage.
+	Repairable bool
+	Count      int   // number of linippets when Err is nil
+	Err        error // *ParseError when the file is broken
+}
+
+// CheckFiles reads every snippet file of the default store, in order,
+// and reports the state of each. A snippet directory is reported as a whole,
+// preceded by its broken files.
+func CheckFiles() ([]FileStatus, error) {
+	paths, err := storePaths()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]FileStatus, 0, len(paths))
+	for _, path := range paths {
+		if path.dir {
+			dirStatuses, err := checkDir(path)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, dirStatuses...)
+			continue
+		}
+		linippets, err := readJson(path.path)
+		statuses = append(statuses, FileStatus{
+			Path:       path.path,
+			ReadOnly:   path.readOnly,
+			Repairable: !path.readOnly,
+			Count:      len(linippets),
+			Err:        err,
+		})
+	}
+	return statuses, nil
+}
+
+func checkDir(dir storePath) ([]FileStatus, error) {
+	paths, err := markdownPaths(dir.path)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []FileStatus
+	count := 0
+	for _, path := range paths {
+		if _, err := readMarkdown(path); err != nil {
+			statuses = append(statuses, FileStatus{Path: path, ReadOnly: dir.readOnly, Err: err})
+			continue
+		}
+		count++
+	}
+	return append(statuses, FileStatus{Path: dir.path, ReadOnly: dir.readOnly, Count: count}), nil
+}
+
+// RepairFile backs up the broken snippet file at path and replaces it with
+// every linippet object which can still be parsed from it. Salvaged objects
+// without an Id are given a new one. It returns the path of the backup and
+// the number of salvaged linippets.
+func RepairFile(path string) (backupPath string, salvaged int, err error) {
+	unlock, err := lockDir(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		deferErr := unlock()
+		if deferErr != nil && err == nil {
+			err = deferErr
+		}
+	}()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed read snippet file: %w", err)
+	}
+	backupPath = fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102150405"))
+	if err := createJson(backupPath, data); err != nil {
+		return "", 0, fmt.Errorf("failed back up snippet file: %w", err)
+	}
+	linippets := salvageLinippets(data)
+	if err := writeStoreFile(path, &storeFile{Snippets: linippets}); err != nil {
+		return backupPath, 0, err
+	}
+	return backupPath, len(linippets), nil
+}
+
+// salvageLinippets decodes every JSON object in data which is a linippet with
+// a snippet, skipping whatever cannot be parsed around them.
+func salvageLinippets(data []byte) Linippets {
+	linippets := Linippets{}
+	for offset := 0; offset < len(data); {
+		start := bytes.IndexByte(data[offset:], '{')
+		if start == -1 {
+			break
+		}
+		start += offset
+		decoder := json.NewDecoder(bytes.NewReader(data[start:]))
+		var l Linippet
+		if err := decoder.Decode(&l); err != nil || l.Snippet == "" {
+			// not a linippet, look for one nested in it or after it
+			offset = start + 1
+			continue
+		}
+		if l.Id == "" {
+			l.Id = uuid.NewString()
+		}
+		linippets = append(linippets, l)
+		offset = start + int(decoder.InputOffset())
+	}
+	return linippets
+}
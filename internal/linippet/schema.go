@@ -0,0 +1,93 @@
+package linippet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// STORE_VERSION is the version of the snippet file format this linippet
+// writes. To change the format, increment it and append the migration from
+// the previous version to migrations.
+const STORE_VERSION = 1
+
+var ErrUnsupportedVersion = errors.New("unsupported snippet file version")
+
+// storeFile is the content of a snippet file.
+type storeFile struct {
+	Version int `json:"version"`
+	// Settings holds store-level settings. Unknown ones are kept as they are.
+	Settings map[string]json.RawMessage `json:"settings,omitempty"`
+	Snippets Linippets                  `json:"snippets"`
+}
+
+// migrations[v] upgrades a decoded snippet file from version v to v+1.
+var migrations = []func(doc any) (any, error){
+	// version 0 is a bare array of linippets
+	func(doc any) (any, error) {
+		return map[string]any{"version": 1, "snippets": doc}, nil
+	},
+}
+
+// decodeStoreFile decodes the content of the snippet file at path, migrating
+// it to STORE_VERSION in memory. Files of a newer version are refused.
+func decodeStoreFile(path string, data []byte) (*storeFile, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, newParseError(path, data, err)
+	}
+	version, err := storeVersion(doc)
+	if err != nil {
+		return nil, fmt.Errorf("snippet file %s: %w", path, err)
+	}
+	if version > STORE_VERSION {
+		return nil, fmt.Errorf("%w: snippet file %s has version %d, but this linippet supports up to version %d. Please upgrade linippet",
+			ErrUnsupportedVersion, path, version, STORE_VERSION)
+	}
+	if version == STORE_VERSION {
+		var s storeFile
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, newParseError(path, data, err)
+		}
+		return &s, nil
+	}
+	for v := version; v < STORE_VERSION; v++ {
+		if doc, err = migrations[v](doc); err != nil {
+			return nil, fmt.Errorf("failed migrate snippet file %s from version %d: %w", path, v, err)
+		}
+	}
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed migrate snippet file %s from version %d: %w", path, version, err)
+	}
+	var s storeFile
+	if err := json.Unmarshal(migrated, &s); err != nil {
+		return nil, fmt.Errorf("failed migrate snippet file %s from version %d: %w", path, version, err)
+	}
+	return &s, nil
+}
+
+// storeVersion returns the format version of a decoded snippet file.
+func storeVersion(doc any) (int, error) {
+	switch doc := doc.(type) {
+	case []any:
+		return 0, nil
+	case map[string]any:
+		version, ok := doc["version"].(float64)
+		if !ok || version < 1 || version != float64(int(version)) {
+			return 0, fmt.Errorf("%w: version is missing or invalid", ErrUnsupportedVersion)
+		}
+		return int(version), nil
+	}
+	return 0, fmt.Errorf("%w: not a snippet file", ErrUnsupportedVersion)
+}
+
+// encodeStoreFile encodes s as a snippet file of STORE_VERSION.
+func encodeStoreFile(s *storeFile) ([]byte, error) {
+	out := *s
+	out.Version = STORE_VERSION
+	if out.Snippets == nil {
+		out.Snippets = Linippets{}
+	}
+	return json.MarshalIndent(&out, "", "  ")
+}
@@ -0,0 +1,105 @@
+package linippet
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMigrationsCoverEveryVersion(t *testing.T) {
+	if len(migrations) != STORE_VERSION {
+		t.Errorf("%d migrations for STORE_VERSION %d, want one per version", len(migrations), STORE_VERSION)
+	}
+}
+
+func TestDecodeStore(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantSnippets []string
+		wantErr      error
+	}{
+		{name: "bare array is upgraded", data: `[{"id":"a","snippet":"echo a"}]`, wantSnippets: []string{"echo a"}},
+		{name: "empty bare array", data: `[]`, wantSnippets: []string{}},
+		{name: "current version", data: `{"version":1,"snippets":[{"id":"a","snippet":"echo a"}]}`, wantSnippets: []string{"echo a"}},
+		{name: "newer version is refused", data: `{"version":2,"snippets":[]}`, wantErr: ErrUnsupportedVersion},
+		{name: "missing version", data: `{"snippets":[]}`, wantErr: ErrUnsupportedVersion},
+		{name: "not a snippet file", data: `"hello"`, wantErr: ErrUnsupportedVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := decodeStoreFile("test.json", []byte(tt.data))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Version != STORE_VERSION {
+				t.Errorf("Version = %d, want %d", s.Version, STORE_VERSION)
+			}
+			got := make([]string, len(s.Snippets))
+			for i, l := range s.Snippets {
+				got[i] = l.Snippet
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantSnippets, ",") {
+				t.Errorf("snippets = %v, want %v", got, tt.wantSnippets)
+			}
+		})
+	}
+}
+
+func TestNewerVersionErrorAsksToUpgrade(t *testing.T) {
+	_, err := decodeStoreFile("test.json", []byte(`{"version":99,"snippets":[]}`))
+	if err == nil || !strings.Contains(err.Error(), "upgrade linippet") {
+		t.Errorf("error = %v, want it to ask to upgrade linippet", err)
+	}
+}
+
+func TestWriteUpgradesAndKeepsSettings(t *testing.T) {
+	setupStores(t)
+	writeFile(t, getJsonPath(), `{"version":1,"settings":{"future":{"x":1}},"snippets":[{"id":"a","snippet":"echo a"}]}`)
+
+	if _, err := NewJsonStore(getJsonPath(), false).Add(Linippet{Snippet: "echo b"}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written struct {
+		Version  int                       `json:"version"`
+		Settings map[string]map[string]int `json:"settings"`
+		Snippets []map[string]any          `json:"snippets"`
+	}
+	if err := json.Unmarshal(b, &written); err != nil {
+		t.Fatal(err)
+	}
+	if written.Version != STORE_VERSION || len(written.Snippets) != 2 {
+		t.Errorf("written = %s", b)
+	}
+	if written.Settings["future"]["x"] != 1 {
+		t.Errorf("settings were not kept: %s", b)
+	}
+}
+
+func TestBareArrayIsWrittenAsEnvelope(t *testing.T) {
+	setupStores(t)
+	writeFile(t, getJsonPath(), `[{"id":"a","snippet":"echo a"}]`)
+
+	if err := NewJsonStore(getJsonPath(), false).Remove("a"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(getJsonPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "{") || !strings.Contains(string(b), `"version": 1`) {
+		t.Errorf("written = %s, want a version 1 envelope", b)
+	}
+}
@@ -0,0 +1,158 @@
+package linippet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Store keeps linippets. Implementations are safe for concurrent use.
+type Store interface {
+	// List returns every linippet.
+	List() (Linippets, error)
+	// Get returns the linippet with id, or ErrNotFound.
+	Get(id string) (Linippet, error)
+	// Add stores l with a new Id and returns the stored linippet.
+	Add(l Linippet) (Linippet, error)
+	// Update replaces the linippet having the Id of l.
+	Update(l Linippet) error
+	// Remove deletes the linippet with id.
+	Remove(id string) error
+	// Watch returns a channel receiving a value whenever the linippets may
+	// have changed. The channel is closed once ctx is done.
+	Watch(ctx context.Context) (<-chan struct{}, error)
+}
+
+// LayeredStore reads several stores as one. Linippets are added to its
+// primary store, and updated or removed in the store they belong to.
+type LayeredStore struct {
+	primary Store
+	stores  []Store
+}
+
+// NewLayeredStore returns a store reading stores in order. primary should be
+// one of stores.
+func NewLayeredStore(primary Store, stores ...Store) *LayeredStore {
+	return &LayeredStore{primary: primary, stores: stores}
+}
+
+// NewDefaultStore returns the store of the snippet files linippet reads: the
+// project snippet files, nearest first, the global store, which is the
+// primary store, and then the read-only collections in LINIPPET_PATH order.
+func NewDefaultStore() (Store, error) {
+	paths, err := storePaths()
+	if err != nil {
+		return nil, err
+	}
+	var primary Store
+	stores := make([]Store, 0, len(paths))
+	for _, path := range paths {
+		store := path.store()
+		if path.global {
+			primary = store
+		}
+		stores = append(stores, store)
+	}
+	return NewLayeredStore(primary, stores...), nil
+}
+
+// List returns the linippets of every store in order. When several stores
+// have a linippet with the same Id, the one of the first store wins and the
+// others are ignored.
+func (s *LayeredStore) List() (Linippets, error) {
+	var linippets Linippets
+	seen := make(map[string]bool)
+	for _, store := range s.stores {
+		storeLinippets, err := store.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range storeLinippets {
+			if seen[l.Id] {
+				continue
+			}
+			seen[l.Id] = true
+			linippets = append(linippets, l)
+		}
+	}
+	return linippets, nil
+}
+
+func (s *LayeredStore) Get(id string) (Linippet, error) {
+	linippets, err := s.List()
+	if err != nil {
+		return Linippet{}, err
+	}
+	targetIndex := slices.IndexFunc(linippets, func(l Linippet) bool {
+		return id == l.Id
+	})
+	if targetIndex == -1 {
+		return Linippet{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return linippets[targetIndex], nil
+}
+
+func (s *LayeredStore) Add(l Linippet) (Linippet, error) {
+	return s.primary.Add(l)
+}
+
+func (s *LayeredStore) Update(l Linippet) error {
+	store, err := s.owner(l.Id)
+	if err != nil {
+		return err
+	}
+	return store.Update(l)
+}
+
+func (s *LayeredStore) Remove(id string) error {
+	store, err := s.owner(id)
+	if err != nil {
+		return err
+	}
+	return store.Remove(id)
+}
+
+// owner returns the first store having the linippet with id.
+func (s *LayeredStore) owner(id string) (Store, error) {
+	for _, store := range s.stores {
+		_, err := store.Get(id)
+		if err == nil {
+			return store, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Watch merges the changes of every store.
+func (s *LayeredStore) Watch(ctx context.Context) (<-chan struct{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	changes := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	for _, store := range s.stores {
+		storeChanges, err := store.Watch(ctx)
+		if err != nil {
+			cancel()
+			wg.Wait()
+			return nil, err
+		}
+		wg.Go(func() {
+			for range storeChanges {
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(changes)
+	}()
+	return changes, nil
+}
@@ -0,0 +1,225 @@
+package linippet
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func snippetsOf(t *testing.T, store Store) []string {
+	t.Helper()
+	linippets, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snippets := make([]string, len(linippets))
+	for i, l := range linippets {
+		snippets[i] = l.Snippet
+	}
+	return snippets
+}
+
+func TestLayeredStore(t *testing.T) {
+	first := NewMemoryStore(Linippet{Id: "a", Snippet: "echo first a"})
+	primary := NewMemoryStore(
+		Linippet{Id: "a", Snippet: "echo primary a"},
+		Linippet{Id: "b", Snippet: "echo primary b"},
+	)
+	store := NewLayeredStore(primary, first, primary)
+
+	if got, want := snippetsOf(t, store), []string{"echo first a", "echo primary b"}; !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+	added, err := store.Add(Linippet{Snippet: "echo added"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Id == "" {
+		t.Error("added linippet has no Id")
+	}
+	if err := store.Update(Linippet{Id: "a", Snippet: "echo updated"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove("b"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := snippetsOf(t, first), []string{"echo updated"}; !slices.Equal(got, want) {
+		t.Errorf("first store = %v, want %v", got, want)
+	}
+	if got, want := snippetsOf(t, primary), []string{"echo primary a", "echo added"}; !slices.Equal(got, want) {
+		t.Errorf("primary store = %v, want %v", got, want)
+	}
+	if _, err := store.Get("b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get error = %v, want ErrNotFound", err)
+	}
+	if err := store.Update(Linippet{Id: "b"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLayeredStoreWatch(t *testing.T) {
+	first := NewMemoryStore()
+	second := NewMemoryStore()
+	store := NewLayeredStore(first, first, second)
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := store.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := second.Add(Linippet{Snippet: "echo a"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+	case <-time.After(3 * time.Second):
+		t.Fatal("no change was notified")
+	}
+	cancel()
+	for range changes {
+	}
+}
+
+func TestJsonStoreWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LINIPPET_DATA_FILE_NAME)
+	store := NewJsonStore(path, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := store.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// another process creating the file is a change too
+	if _, err := NewJsonStore(path, false).Add(Linippet{Snippet: "echo a"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+	case <-time.After(3 * time.Second):
+		t.Fatal("no change was notified")
+	}
+	if got, want := snippetsOf(t, store), []string{"echo a"}; !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+}
+
+func TestJsonStoreMissingFile(t *testing.T) {
+	store := NewJsonStore(filepath.Join(t.TempDir(), "missing.json"), true)
+	if got := snippetsOf(t, store); len(got) != 0 {
+		t.Errorf("List = %v, want empty", got)
+	}
+	if _, err := store.Add(Linippet{Snippet: "echo a"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Add error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestDirStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), SNIPPET_DIR_NAME)
+	store := NewDirStore(dir, false)
+	if got := snippetsOf(t, store); len(got) != 0 {
+		t.Errorf("List = %v, want empty", got)
+	}
+	a, err := store.Add(Linippet{Snippet: "echo a", Tags: []string{"x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Source != filepath.Join(dir, "0001-"+a.Id+MARKDOWN_EXT) {
+		t.Errorf("Source = %q", a.Source)
+	}
+	// a file added by hand takes its file name as its id
+	writeFile(t, filepath.Join(dir, "b.md"), "---\ndescription: b\n---\n\n```sh\necho b\n```\n")
+	a.Snippet = "echo updated"
+	if err := store.Update(a); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Get(a.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Snippet != "echo updated" || !slices.Equal(got.Tags, []string{"x"}) {
+		t.Errorf("Get = %+v", got)
+	}
+	// snippets are listed in the order they are added, before files without
+	// an order number
+	if _, err := store.Add(Linippet{Snippet: "echo c"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := snippetsOf(t, store), []string{"echo updated", "echo c", "echo b"}; !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+	if err := store.Remove("b"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := snippetsOf(t, store), []string{"echo updated", "echo c"}; !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+	if err := store.Remove("b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDefaultStoreDir(t *testing.T) {
+	setupStores(t)
+	t.Setenv(STORE_ENV_NAME, STORE_KIND_DIR)
+	store := defaultStore(t)
+	added, err := store.Add(Linippet{Snippet: "echo a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(added.Source) != getDirPath() || SourceLabel(added.Source) != "" {
+		t.Errorf("Source = %q", added.Source)
+	}
+	if _, err := os.Stat(getJsonPath()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("JSON snippet file was created: %v", err)
+	}
+
+	t.Setenv(STORE_ENV_NAME, "sqlite")
+	if _, err := NewDefaultStore(); err == nil {
+		t.Error("NewDefaultStore succeeded with an unsupported store kind")
+	}
+}
+
+func TestMigrateRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	from := filepath.Join(root, "from.json")
+	writeFile(t, from, `{"version":1,"snippets":[
+		{"id":"a","snippet":"echo ${{name:x}}","description":"A","tags":["t"]},
+		{"id":"b/c","snippet":"ls\n"},
+		{"id":"B","snippet":"echo `+"```"+`"}
+	]}`)
+	dir := filepath.Join(root, "snippets")
+	back := filepath.Join(root, "back.json")
+
+	if count, err := Migrate(from, dir); err != nil || count != 3 {
+		t.Fatalf("Migrate to dir = %d, %v", count, err)
+	}
+	if _, err := Migrate(from, dir); err == nil {
+		t.Error("Migrate overwrote a directory having snippets")
+	}
+	if count, err := Migrate(dir, back); err != nil || count != 3 {
+		t.Fatalf("Migrate to json = %d, %v", count, err)
+	}
+
+	want, err := readJson(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := readJson(back)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d snippets, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if g := got[i]; g.Id != w.Id || g.Snippet != w.Snippet || g.Description != w.Description || !slices.Equal(g.Tags, w.Tags) {
+			t.Errorf("migrated %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package linippet
+
+import (
+	"slices"
+	"strings"
+)
+
+// ParseTags splits text separated by commas or whitespace into tags. A leading
+// "#" is dropped and duplicated tags are kept only once.
+func ParseTags(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	var tags []string
+	for _, field := range fields {
+		tag := strings.TrimLeft(field, "#")
+		if tag == "" || slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// HasTags reports whether l has every tag in tags, ignoring case.
+func (l Linippet) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.ContainsFunc(l.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterByTags returns the linippets having every tag in tags.
+func (linippets Linippets) FilterByTags(tags []string) Linippets {
+	if len(tags) == 0 {
+		return linippets
+	}
+	filtered := make(Linippets, 0, len(linippets))
+	for _, l := range linippets {
+		if l.HasTags(tags) {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered
+}
@@ -1,15 +1,29 @@
 package linippet
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 )
 
 const (
 	ENV_NAME                = "LINIPPET_DATA"
+	PATH_ENV_NAME           = "LINIPPET_PATH"
 	DEFAULT_LINIPPET_DIR    = ".linippet"
 	LINIPPET_DATA_FILE_NAME = "linippet.json"
+	PROJECT_FILE_NAME       = ".linippet.json"
+	STORE_ENV_NAME          = "LINIPPET_STORE"
+	SNIPPET_DIR_NAME        = "snippets"
+)
+
+// kinds of the global store, selected with LINIPPET_STORE
+const (
+	STORE_KIND_JSON = "json"
+	STORE_KIND_DIR  = "dir"
 )
 
 func getJsonPath() string {
@@ -21,6 +35,12 @@ func getJsonPath() string {
 	return filepath.Join(homeDir, DEFAULT_LINIPPET_DIR, LINIPPET_DATA_FILE_NAME)
 }
 
+// getDirPath returns the directory of the global store when LINIPPET_STORE is
+// "dir".
+func getDirPath() string {
+	return filepath.Join(filepath.Dir(getJsonPath()), SNIPPET_DIR_NAME)
+}
+
 func checkJsonPath() (dataPath string, err error) {
 	dataPath = getJsonPath()
 	// If data file not exists, create with initial value
@@ -30,19 +50,196 @@ func checkJsonPath() (dataPath string, err error) {
 		if err != nil {
 			return "", err
 		}
-		file, err := os.Create(dataPath)
+		initial, err := encodeStoreFile(&storeFile{})
 		if err != nil {
 			return "", err
 		}
-		defer func() {
-			deferErr := file.Close()
-			if deferErr != nil {
-				err = deferErr
-			}
-		}()
-		if _, err := file.Write([]byte("[]")); err != nil {
+		if err := createJson(dataPath, initial); err != nil {
 			return "", err
 		}
 	}
 	return dataPath, nil
 }
+
+// createJson creates the file at dataPath with data unless it already exists.
+// The file appears with its whole content at once, and a file created
+// concurrently by another process is left as it is.
+func createJson(dataPath string, data []byte) (err error) {
+	file, err := os.CreateTemp(filepath.Dir(dataPath), "."+filepath.Base(dataPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferErr := os.Remove(file.Name())
+		if deferErr != nil && err == nil {
+			err = deferErr
+		}
+	}()
+	if err := file.Chmod(0644); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Link(file.Name(), dataPath); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+// storePath is a snippet file or directory of the default store.
+type storePath struct {
+	path     string
+	readOnly bool
+	dir      bool
+	global   bool
+}
+
+func (p storePath) store() Store {
+	if p.dir {
+		return NewDirStore(p.path, p.readOnly)
+	}
+	return NewJsonStore(p.path, p.readOnly)
+}
+
+// storePaths returns every snippet file and directory in reading order: the
+// project snippet files, nearest first, the global store and then the
+// read-only collections in LINIPPET_PATH order.
+func storePaths() ([]storePath, error) {
+	var paths []storePath
+	for _, path := range findProjectJsonPaths() {
+		paths = append(paths, storePath{path: path})
+	}
+	global, err := globalStorePath()
+	if err != nil {
+		return nil, err
+	}
+	paths = append(paths, global)
+	for _, path := range findCollectionJsonPaths() {
+		paths = append(paths, storePath{path: path, readOnly: true})
+	}
+	return paths, nil
+}
+
+// globalStorePath returns the global store of the kind LINIPPET_STORE
+// selects, the JSON snippet file by default.
+func globalStorePath() (storePath, error) {
+	switch kind := os.Getenv(STORE_ENV_NAME); kind {
+	case "", STORE_KIND_JSON:
+		dataPath, err := checkJsonPath()
+		if err != nil {
+			return storePath{}, err
+		}
+		return storePath{path: dataPath, global: true}, nil
+	case STORE_KIND_DIR:
+		return storePath{path: getDirPath(), dir: true, global: true}, nil
+	default:
+		return storePath{}, fmt.Errorf("%s=%s is unsupported, use %s or %s", STORE_ENV_NAME, kind, STORE_KIND_JSON, STORE_KIND_DIR)
+	}
+}
+
+// projectDirs returns the directories searched for a project snippet file:
+// the working directory and its parents, up to the git root. The search
+// stops below the home directory.
+func projectDirs() []string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	homeDir, _ := os.UserHomeDir()
+	var dirs []string
+	for dir != homeDir {
+		dirs = append(dirs, dir)
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return dirs
+}
+
+// findProjectJsonPaths returns the existing project snippet files, nearest
+// first.
+func findProjectJsonPaths() []string {
+	var paths []string
+	for _, dir := range projectDirs() {
+		projectPath := filepath.Join(dir, PROJECT_FILE_NAME)
+		if info, err := os.Stat(projectPath); err == nil && !info.IsDir() {
+			paths = append(paths, projectPath)
+		}
+	}
+	return paths
+}
+
+// ProjectJsonPath returns the nearest project snippet file. When there is
+// none, it returns the path to create one at: the git root, or the working
+// directory outside a repository.
+func ProjectJsonPath() (string, error) {
+	if paths := findProjectJsonPaths(); len(paths) > 0 {
+		return paths[0], nil
+	}
+	dirs := projectDirs()
+	if len(dirs) == 0 {
+		return "", errors.New("project snippet file cannot be placed in the home directory")
+	}
+	root := dirs[len(dirs)-1]
+	if _, err := os.Stat(filepath.Join(root, ".git")); err != nil {
+		root = dirs[0]
+	}
+	return filepath.Join(root, PROJECT_FILE_NAME), nil
+}
+
+// findCollectionJsonPaths returns the snippet files of the collections listed
+// in LINIPPET_PATH, in order. A directory stands for the *.json files directly
+// in it, sorted by name. Entries which do not exist are skipped.
+func findCollectionJsonPaths() []string {
+	var paths []string
+	for _, entry := range filepath.SplitList(os.Getenv(PATH_ENV_NAME)) {
+		if entry == "" {
+			continue
+		}
+		info, err := os.Stat(entry)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			paths = append(paths, filepath.Clean(entry))
+			continue
+		}
+		// Glob returns the matches sorted
+		matches, _ := filepath.Glob(filepath.Join(entry, "*.json"))
+		paths = append(paths, matches...)
+	}
+	return paths
+}
+
+// SourceLabel returns a short name of a snippet file for display. It is empty
+// for the global snippet file, relative to the working directory for project
+// snippet files, and the path with the home directory abbreviated otherwise.
+func SourceLabel(source string) string {
+	if source == "" || filepath.Clean(source) == getJsonPath() || filepath.Dir(source) == getDirPath() {
+		return ""
+	}
+	if filepath.Base(source) == PROJECT_FILE_NAME {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, source); err == nil {
+				return rel
+			}
+		}
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(homeDir, source); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join("~", rel)
+		}
+	}
+	return source
+}
@@ -3,6 +3,7 @@ package snippet
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -11,8 +12,11 @@ var (
 	NoLabelRegexp     = regexp.MustCompile(`^\s\s(.+)`)
 	ExtractArgsRegexp = regexp.MustCompile(`\${{(\w+)(?::([^}]*))?}}`)
 	ReplaceRegexp     = regexp.MustCompile(`(\${{[^}]*}})`)
+	placeholderRegexp = regexp.MustCompile(`^\${{\w+(?::[^}]*)?}}`)
 )
 
+const placeholderStart = "${{"
+
 type Arg struct {
 	Name    string
 	Default string
@@ -30,6 +34,40 @@ func ExtractSnippetArgsWithDefaults(snippet string) []Arg {
 	return args
 }
 
+// UniqueArgs returns the arguments of the placeholders in snippet, each name
+// once, with the first default given to it.
+func UniqueArgs(snippet string) []Arg {
+	var args []Arg
+	argIndex := make(map[string]int)
+	for _, arg := range ExtractSnippetArgsWithDefaults(snippet) {
+		index, ok := argIndex[arg.Name]
+		if !ok {
+			argIndex[arg.Name] = len(args)
+			args = append(args, arg)
+			continue
+		}
+		if args[index].Default == "" {
+			args[index].Default = arg.Default
+		}
+	}
+	return args
+}
+
+// LongestRun returns the length of the longest run of c in s, to fence a
+// snippet with more backticks than it contains.
+func LongestRun(s string, c byte) int {
+	longest, current := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != c {
+			current = 0
+			continue
+		}
+		current++
+		longest = max(longest, current)
+	}
+	return longest
+}
+
 func ReplaceSnippet(snippet string, args []string) (string, error) {
 	result := snippet
 	if len(args) == 0 {
@@ -48,6 +86,50 @@ func ReplaceSnippet(snippet string, args []string) (string, error) {
 	return result, nil
 }
 
+// FillSnippet replaces the placeholders of snippet with values by argument
+// name. Arguments without a value take their default, the first one given
+// to the name. It fails listing the arguments having neither, and on values
+// for arguments the snippet does not have.
+func FillSnippet(snippet string, values map[string]string) (string, error) {
+	if err := ValidatePlaceholders(snippet); err != nil {
+		return snippet, err
+	}
+	defaults := make(map[string]string)
+	for _, arg := range UniqueArgs(snippet) {
+		defaults[arg.Name] = arg.Default
+	}
+	args := ExtractSnippetArgsWithDefaults(snippet)
+	var unknown []string
+	for name := range values {
+		if _, ok := defaults[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return snippet, fmt.Errorf("unknown arguments: %s", strings.Join(unknown, ", "))
+	}
+	if len(args) == 0 {
+		return snippet, nil
+	}
+	filled := make([]string, len(args))
+	var missing []string
+	for i, arg := range args {
+		value, ok := values[arg.Name]
+		if !ok {
+			value = defaults[arg.Name]
+		}
+		if value == "" && !ok && !slices.Contains(missing, arg.Name) {
+			missing = append(missing, arg.Name)
+		}
+		filled[i] = value
+	}
+	if len(missing) > 0 {
+		return snippet, fmt.Errorf("missing required arguments: %s", strings.Join(missing, ", "))
+	}
+	return ReplaceSnippet(snippet, filled)
+}
+
 // Validate snipppet is one-liner
 // One-liner means that it does not contain any newline characters."
 // However, line ending character strings are permitted.”
@@ -57,3 +139,32 @@ func ValidateSnippet(snippet string) error {
 	}
 	return nil
 }
+
+// ValidatePlaceholders checks that every "${{" starts a placeholder
+// ${{name}} or ${{name:default}}, where name is made of letters, digits and
+// underscores and default does not contain "}".
+func ValidatePlaceholders(snippet string) error {
+	offset := 0
+	for {
+		index := strings.Index(snippet[offset:], placeholderStart)
+		if index == -1 {
+			return nil
+		}
+		start := offset + index
+		match := placeholderRegexp.FindString(snippet[start:])
+		if match == "" {
+			return fmt.Errorf("invalid placeholder at column %d: %s (use ${{name}} or ${{name:default}})",
+				start+1, placeholderExcerpt(snippet[start:]))
+		}
+		offset = start + len(match)
+	}
+}
+
+// placeholderExcerpt returns the beginning of text up to the end of the
+// broken placeholder it starts with.
+func placeholderExcerpt(text string) string {
+	if end := strings.Index(text, "}}"); end != -1 {
+		return text[:end+len("}}")]
+	}
+	return text
+}
@@ -64,6 +64,45 @@ func TestReplaceSnippet(t *testing.T) {
 	}
 }
 
+func TestFillSnippet(t *testing.T) {
+	tests := []struct {
+		name            string
+		snippet         string
+		values          map[string]string
+		expected        string
+		isOccurredError bool
+	}{
+		{name: "no args", snippet: "ls -la", values: nil, expected: "ls -la", isOccurredError: false},
+		{name: "fill by name", snippet: "ls ${{option}} ${{dir}}", values: map[string]string{"dir": "/tmp", "option": "-l"}, expected: "ls -l /tmp", isOccurredError: false},
+		{name: "apply default", snippet: "git log -n ${{count:10}}", values: nil, expected: "git log -n 10", isOccurredError: false},
+		{name: "override default", snippet: "git log -n ${{count:10}}", values: map[string]string{"count": "3"}, expected: "git log -n 3", isOccurredError: false},
+		{name: "same name filled everywhere", snippet: "cp ${{f}} ${{f:x}}.bak", values: nil, expected: "cp x x.bak", isOccurredError: false},
+		{name: "explicit empty value", snippet: "ls ${{option}}", values: map[string]string{"option": ""}, expected: "ls ", isOccurredError: false},
+		{name: "missing required", snippet: "ssh ${{host}} ${{port:22}}", values: nil, expected: "ssh ${{host}} ${{port:22}}", isOccurredError: true},
+		{name: "unknown arg", snippet: "ls ${{dir:.}}", values: map[string]string{"dri": "/tmp"}, expected: "ls ${{dir:.}}", isOccurredError: true},
+		{name: "invalid placeholder", snippet: "ls ${{bad name}}", values: nil, expected: "ls ${{bad name}}", isOccurredError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FillSnippet(tt.snippet, tt.values)
+			if err != nil != tt.isOccurredError {
+				t.Errorf("unexpected error: %+v", err)
+				return
+			}
+			if result != tt.expected {
+				t.Errorf("result is %+v, but expected is %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFillSnippetListsMissingArgs(t *testing.T) {
+	_, err := FillSnippet("scp ${{file}} ${{host}}:${{dir:~}} ${{file}}", nil)
+	if err == nil || err.Error() != "missing required arguments: file, host" {
+		t.Errorf("error is %v", err)
+	}
+}
+
 func TestValidateSnippet(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -88,3 +127,43 @@ func TestValidateSnippet(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePlaceholders(t *testing.T) {
+	tests := []struct {
+		name            string
+		snippet         string
+		isOccurredError bool
+	}{
+		{name: "no placeholders", snippet: "ls -la", isOccurredError: false},
+		{name: "shell variables", snippet: "echo ${HOME} $PATH {{x}}", isOccurredError: false},
+		{name: "valid placeholders", snippet: "git log -n ${{count:10}} ${{ref}} ${{url:http://a:80}}", isOccurredError: false},
+		{name: "empty default", snippet: "echo ${{arg:}}", isOccurredError: false},
+		{name: "empty name", snippet: "echo ${{}}", isOccurredError: true},
+		{name: "space in name", snippet: "echo ${{first name}}", isOccurredError: true},
+		{name: "unclosed", snippet: "echo ${{name", isOccurredError: true},
+		{name: "closed with one brace", snippet: "echo ${{name} ${{b}}", isOccurredError: true},
+		{name: "invalid after valid", snippet: "echo ${{a}} ${{-b}}", isOccurredError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePlaceholders(tt.snippet)
+			if (err != nil) != tt.isOccurredError {
+				t.Errorf("In spite of isOccurredError = %+v, error occurred: %+v", tt.isOccurredError, err)
+			}
+		})
+	}
+}
+
+func TestUniqueArgs(t *testing.T) {
+	got := UniqueArgs("git log -n ${{count}} ${{branch:main}} -- ${{count:10}} ${{branch:dev}}")
+	want := []Arg{{Name: "count", Default: "10"}, {Name: "branch", Default: "main"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UniqueArgs = %+v, want %+v", got, want)
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	if got := LongestRun("echo `a` ``` ``", '`'); got != 3 {
+		t.Errorf("LongestRun = %d, want 3", got)
+	}
+}
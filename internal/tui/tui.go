@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/muleyuck/linippet/internal/fuzzy_search"
@@ -11,44 +12,126 @@ import (
 	"github.com/muleyuck/linippet/internal/tui/widget"
 )
 
-const FOCUS_LABEL = "> "
+const (
+	FOCUS_LABEL  = "> "
+	MARKED_LABEL = "+ "
+)
+
+// input field indices of the snippet form used by create and edit
+const (
+	snippetFieldIndex = iota
+	descriptionFieldIndex
+	tagsFieldIndex
+	nameFieldIndex
+)
 
 type tui struct {
 	app          *widget.App
 	Result       string
+	Description  string
+	Tags         []string
+	Name         string
 	linippetArgs []string
 	Submit       bool
 }
 
+// newSnippetFormModal returns a modal with the snippet, description, tags and
+// name fields filled with initial. Changes are reflected to Result,
+// Description, Tags and Name. A read-only initial is told to be saved as a
+// copy, whose name is left empty as the original keeps it.
+func (t *tui) newSnippetFormModal(initial linippet.Linippet) *widget.Modal {
+	if initial.ReadOnly {
+		initial.Name = ""
+	}
+	modal := widget.NewModal().
+		AddInputFields(
+			[]string{"Snippet", "Description", "Tags", "Name"},
+			[]string{initial.Snippet, initial.Description, strings.Join(initial.Tags, ", "), initial.Name},
+		).
+		AddTextView("Syntax: ${{name}} or ${{name:default}}").
+		AddButtons([]string{"OK", "Cancel"}).
+		SetText("$ " + snippetPreviewText(initial.Snippet))
+	if initial.ReadOnly {
+		modal.AddTextView("Read-only snippet: OK saves a copy to your snippets")
+	}
+
+	t.Result = initial.Snippet
+	t.Description = initial.Description
+	t.Tags = initial.Tags
+	t.Name = initial.Name
+	modal.SetChangedFunc(func(inputIndex int, inputValue string) {
+		switch inputIndex {
+		case snippetFieldIndex:
+			t.Result = inputValue
+			modal.SetText("$ " + snippetPreviewText(inputValue))
+		case descriptionFieldIndex:
+			t.Description = inputValue
+		case tagsFieldIndex:
+			t.Tags = linippet.ParseTags(inputValue)
+		case nameFieldIndex:
+			t.Name = strings.TrimSpace(inputValue)
+		}
+	})
+	return modal
+}
+
 type OnlyModalTui struct {
 	*tui
 	modal *widget.Modal
+	// submitFunc is called when OK is chosen.
+	submitFunc func()
 }
 
-func NewCreateTui() *OnlyModalTui {
-	app := widget.NewApp()
+// NewCreateTui returns a tui showing the snippet form, with the snippet field
+// filled with initial.
+func NewCreateTui(initial string) *OnlyModalTui {
+	return NewFormTui(linippet.Linippet{Snippet: initial})
+}
+
+// NewFormTui returns a tui showing only the snippet form, filled with initial.
+func NewFormTui(initial linippet.Linippet) *OnlyModalTui {
+	t := &tui{app: widget.NewApp()}
+	return newOnlyModalTui(t, t.newSnippetFormModal(initial))
+}
+
+// NewConfirmTui returns a tui asking to confirm with text. Submit is set when
+// OK is chosen.
+func NewConfirmTui(text string) *OnlyModalTui {
 	modal := widget.NewModal().
-		AddInputFields([]string{""}, nil).
-		AddTextView("Syntax: ${{name}} or ${{name:default}}").
 		AddButtons([]string{"OK", "Cancel"}).
-		SetText("$ ")
-	app.SetRoot(modal)
+		SetText(text)
+	return newOnlyModalTui(&tui{app: widget.NewApp()}, modal)
+}
+
+// NewArgsTui returns a tui showing only the arguments modal of target, as the
+// root tui does once target is chosen. Result is set to the filled snippet
+// when OK is chosen.
+func NewArgsTui(target linippet.Linippet) *OnlyModalTui {
+	t := &tui{app: widget.NewApp()}
+	o := newOnlyModalTui(t, t.newArgsModal(target.Snippet))
+	o.submitFunc = func() {
+		t.Result = t.fillArgs(target.Snippet)
+	}
+	return o
+}
+
+func newOnlyModalTui(t *tui, modal *widget.Modal) *OnlyModalTui {
+	t.app.SetRoot(modal)
 	return &OnlyModalTui{
-		tui:   &tui{app: app},
+		tui:   t,
 		modal: modal,
 	}
 }
 
 func (t *OnlyModalTui) SetAction() {
-	t.modal.SetChangedFunc(func(inputIndex int, inputValue string) {
-		t.Result = inputValue
-		t.modal.SetText("$ " + snippetPreviewText(inputValue))
-	})
 	t.modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 		if buttonLabel == "Cancel" || buttonIndex == -1 {
 			t.app.Stop()
 		} else if buttonLabel == "OK" {
 			t.Submit = true
+			if t.submitFunc != nil {
+				t.submitFunc()
+			}
 			t.app.Stop()
 		}
 	})
@@ -76,31 +159,68 @@ type listModalTui struct {
 	layout       *widget.VerticalLayout
 	input        *widget.InputField
 	list         *widget.List
+	store        linippet.Store
 	linippets    linippet.Linippets
-	modalFunc    func(string) *widget.Modal
+	tags         []string
+	loadErr      error
+	modalFunc    func(linippet.Linippet) *widget.Modal
 	SelectId     string
 	searchCancel context.CancelFunc
+	watchCancel  context.CancelFunc
+	// multiSelect lets Tab mark several linippets, which Enter submits as
+	// SelectIds.
+	multiSelect  bool
+	marked       map[string]bool
+	SelectIds    []string
+	describeFunc func(linippet.Linippet) string
 }
 
-func NewRootTui() *listModalTui {
-	m := newListModalTui()
+func NewRootTui(store linippet.Store) *listModalTui {
+	m := newListModalTui(store)
 	m.modalFunc = m.setRootModal
 	return m
 }
 
-func NewEditTui() *listModalTui {
-	m := newListModalTui()
+func NewEditTui(store linippet.Store) *listModalTui {
+	m := newListModalTui(store)
 	m.modalFunc = m.setEditModal
 	return m
 }
 
-func NewRemoveTui() *listModalTui {
-	m := newListModalTui()
+func NewRemoveTui(store linippet.Store) *listModalTui {
+	m := newListModalTui(store)
 	m.modalFunc = m.setRemoveModal
 	return m
 }
 
-func newListModalTui() *listModalTui {
+// NewMultiSelectTui returns a list where Tab marks linippets and Enter submits
+// the marked ones, or the current one when none is marked.
+func NewMultiSelectTui(store linippet.Store) *listModalTui {
+	m := newListModalTui(store)
+	m.multiSelect = true
+	m.marked = make(map[string]bool)
+	m.list.SetMarkedLabel(MARKED_LABEL)
+	return m
+}
+
+// SetDescriptionFunc replaces the text shown after each snippet in the list.
+func (t *listModalTui) SetDescriptionFunc(describe func(linippet.Linippet) string) {
+	t.describeFunc = describe
+}
+
+// SetTags restricts the listed linippets to those having every tag in tags.
+// It must be called before LazyLoadLinippet.
+func (t *listModalTui) SetTags(tags []string) {
+	t.tags = tags
+}
+
+// SetQuery fills the search input with query, so that the list opens
+// filtered by it.
+func (t *listModalTui) SetQuery(query string) {
+	t.input.SetText(query)
+}
+
+func newListModalTui(store linippet.Store) *listModalTui {
 	app := widget.NewApp()
 
 	input := widget.NewInputField().
@@ -124,6 +244,7 @@ func newListModalTui() *listModalTui {
 	app.SetRoot(layout)
 	return &listModalTui{
 		tui:    &tui{app: app},
+		store:  store,
 		layout: layout,
 		list:   list,
 		input:  input,
@@ -133,10 +254,22 @@ func newListModalTui() *listModalTui {
 func (t *listModalTui) SetAction() {
 	t.input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
-		case tcell.KeyDown, tcell.KeyCtrlN, tcell.KeyTab:
+		case tcell.KeyTab:
+			if t.multiSelect {
+				t.toggleMark()
+			}
 			t.offsetItem(1)
 			return nil
-		case tcell.KeyUp, tcell.KeyCtrlP, tcell.KeyBacktab:
+		case tcell.KeyBacktab:
+			if t.multiSelect {
+				t.toggleMark()
+			}
+			t.offsetItem(-1)
+			return nil
+		case tcell.KeyDown, tcell.KeyCtrlN:
+			t.offsetItem(1)
+			return nil
+		case tcell.KeyUp, tcell.KeyCtrlP:
 			t.offsetItem(-1)
 			return nil
 		case tcell.KeyEnter:
@@ -145,9 +278,13 @@ func (t *listModalTui) SetAction() {
 				t.app.Stop()
 				return nil
 			}
-			currentText, linippetId := t.list.GetItemText(currentIndex)
+			_, linippetId := t.list.GetItemText(currentIndex)
 			t.SelectId = linippetId
-			modal := t.modalFunc(currentText)
+			if t.multiSelect {
+				t.submitMarked()
+				return nil
+			}
+			modal := t.modalFunc(t.findLinippet(linippetId))
 			if modal == nil {
 				t.app.Stop()
 				return nil
@@ -158,49 +295,112 @@ func (t *listModalTui) SetAction() {
 		}
 		return event
 	})
-	t.input.SetChangedFunc(func(text string) {
-		if t.searchCancel != nil {
-			t.searchCancel()
+	t.input.SetChangedFunc(t.search)
+}
+
+// search lists the loaded linippets matching text. An empty text lists all of
+// them in order. The search runs in the background and only the result for
+// the current input is shown.
+func (t *listModalTui) search(text string) {
+	if t.searchCancel != nil {
+		t.searchCancel()
+	}
+
+	if len(text) <= 0 {
+		t.searchCancel = nil
+		t.list.Clear()
+		for _, linippet := range t.linippets {
+			t.addItem(linippet, nil, nil)
 		}
+		t.setTitle(len(t.linippets), len(t.linippets))
+		return
+	}
 
-		if len(text) <= 0 {
-			t.searchCancel = nil
-			t.list.Clear()
-			for _, linippet := range t.linippets {
-				t.addItem(linippet.Snippet, linippet.Id, nil)
-			}
-			t.list.SetTitle(fmt.Sprintf(" %d/%d ", len(t.linippets), len(t.linippets)))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.searchCancel = cancel
+	linippets := t.linippets
+	go func() {
+		sorted := fuzzy_search.Search(ctx, text, linippets)
+		if sorted == nil {
 			return
 		}
-
-		ctx, cancel := context.WithCancel(context.Background())
-		t.searchCancel = cancel
-		go func() {
-			sorted := fuzzy_search.FuzzySearch(ctx, text, t.linippets)
-			if sorted == nil {
+		t.app.QueueUpdateDraw(func() {
+			if t.input.GetText() != text {
 				return
 			}
-			t.app.QueueUpdateDraw(func() {
-				if t.input.GetText() != text {
-					return
-				}
-				t.list.Clear()
-				for _, result := range sorted {
-					t.addItem(result.Linippet.Snippet, result.Linippet.Id, result.Matches)
-				}
-				t.list.SetTitle(fmt.Sprintf(" %d/%d ", len(sorted), len(t.linippets)))
-			})
-		}()
-	})
+			t.list.Clear()
+			for _, result := range sorted {
+				t.addItem(result.Linippet, result.Matches, result.DescriptionMatches)
+			}
+			t.setTitle(len(sorted), len(linippets))
+		})
+	}()
 }
 
+func (t *listModalTui) setTitle(count, total int) {
+	if t.multiSelect {
+		t.list.SetTitle(fmt.Sprintf(" %d/%d (%d selected) ", count, total, len(t.marked)))
+		return
+	}
+	t.list.SetTitle(fmt.Sprintf(" %d/%d ", count, total))
+}
+
+// toggleMark marks the current item, or unmarks it when it is marked.
+func (t *listModalTui) toggleMark() {
+	currentIndex := t.list.GetCurrentItem()
+	if t.list.GetItemCount() <= currentIndex {
+		return
+	}
+	_, linippetId := t.list.GetItemText(currentIndex)
+	if t.marked[linippetId] {
+		delete(t.marked, linippetId)
+	} else {
+		t.marked[linippetId] = true
+	}
+	t.list.SetItemMarked(currentIndex, t.marked[linippetId])
+	t.setTitle(t.list.GetItemCount(), len(t.linippets))
+}
+
+// submitMarked submits the marked linippets in loaded order, or the current
+// one when none is marked.
+func (t *listModalTui) submitMarked() {
+	t.SelectIds = nil
+	for _, l := range t.linippets {
+		if t.marked[l.Id] {
+			t.SelectIds = append(t.SelectIds, l.Id)
+		}
+	}
+	if len(t.SelectIds) == 0 {
+		t.SelectIds = []string{t.SelectId}
+	}
+	t.Submit = true
+	t.app.Stop()
+}
+
+// SelectedLinippets returns the linippets submitted in multi-select mode.
+func (t *listModalTui) SelectedLinippets() linippet.Linippets {
+	linippets := make(linippet.Linippets, 0, len(t.SelectIds))
+	for _, id := range t.SelectIds {
+		linippets = append(linippets, t.findLinippet(id))
+	}
+	return linippets
+}
+
+// SelectedLinippet returns the linippet chosen from the list.
+func (t *listModalTui) SelectedLinippet() linippet.Linippet {
+	return t.findLinippet(t.SelectId)
+}
+
+// StartApp runs the app until it is stopped. It returns the error of
+// LazyLoadLinippet when the linippets could not be loaded.
 func (t *listModalTui) StartApp() error {
 	t.app.SetFocus(t.input)
+	defer t.stopWatch()
 	if err := t.app.Run(); err != nil {
 		t.app.Stop()
 		return err
 	}
-	return nil
+	return t.loadErr
 }
 
 func mod(a, b int) int {
@@ -221,68 +421,125 @@ func (t *listModalTui) offsetItem(offset int) {
 	t.list.SetCurrentItem(distIndex)
 }
 
-func (t *listModalTui) addItem(mainText string, secondaryText string, matchIndices []int) {
-	t.list.AddItem(mainText, secondaryText, matchIndices)
+func (t *listModalTui) addItem(l linippet.Linippet, matchIndices []int, descriptionMatchIndices []int) {
+	t.list.AddItem(l.Snippet, l.Id, matchIndices)
+	index := t.list.GetItemCount() - 1
+	describe := itemDescription
+	if t.describeFunc != nil {
+		describe = t.describeFunc
+	}
+	if description := describe(l); description != "" {
+		t.list.SetItemDescription(index, description, descriptionMatchIndices)
+	}
+	if t.marked[l.Id] {
+		t.list.SetItemMarked(index, true)
+	}
+}
+
+// itemDescription returns the text shown after a snippet in the list: its
+// description, its name, its tags and the snippet file it comes from.
+func itemDescription(l linippet.Linippet) string {
+	parts := make([]string, 0, len(l.Tags)+3)
+	if l.Description != "" {
+		parts = append(parts, l.Description)
+	}
+	if l.Name != "" {
+		parts = append(parts, "@"+l.Name)
+	}
+	for _, tag := range l.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if label := linippet.SourceLabel(l.Source); label != "" {
+		parts = append(parts, "["+label+"]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// findLinippet returns the loaded linippet with id, or a zero Linippet when
+// there is none.
+func (t *listModalTui) findLinippet(id string) linippet.Linippet {
+	for _, l := range t.linippets {
+		if l.Id == id {
+			return l
+		}
+	}
+	return linippet.Linippet{}
 }
 
+// LazyLoadLinippet loads the linippets from the store in the background and
+// reloads them whenever the store changes while the app runs.
 func (t *listModalTui) LazyLoadLinippet() {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.watchCancel = cancel
 	go func() {
-		linippets, err := linippet.ReadLinippets()
+		// watch before the first load not to miss a change in between
+		changes, err := t.store.Watch(ctx)
 		if err != nil {
-			panic(err)
+			t.stopWithLoadError(err)
+			return
 		}
-		t.app.QueueUpdateDraw(func() {
-			for _, linippet := range linippets {
-				t.addItem(linippet.Snippet, linippet.Id, nil)
+		if err := t.reload(); err != nil {
+			t.stopWithLoadError(err)
+			return
+		}
+		for range changes {
+			if err := t.reload(); err != nil {
+				t.stopWithLoadError(err)
+				return
 			}
-			t.list.SetTitle(fmt.Sprintf(" %d/%d ", len(linippets), len(linippets)))
-		})
-		t.linippets = linippets
+		}
 	}()
 }
 
+// reload lists the linippets of the store and shows them for the current
+// input.
+func (t *listModalTui) reload() error {
+	linippets, err := t.store.List()
+	if err != nil {
+		return err
+	}
+	linippets = linippets.FilterByTags(t.tags)
+	t.app.QueueUpdateDraw(func() {
+		t.linippets = linippets
+		t.search(t.input.GetText())
+	})
+	return nil
+}
+
+func (t *listModalTui) stopWithLoadError(err error) {
+	t.app.QueueUpdateDraw(func() {
+		t.loadErr = err
+		t.app.Stop()
+	})
+}
+
+// stopWatch stops watching the store for changes.
+func (t *listModalTui) stopWatch() {
+	if t.watchCancel != nil {
+		t.watchCancel()
+	}
+}
+
 func (t *listModalTui) closeModal() {
 	t.layout.RemoveOverlay()
 	t.app.SetFocus(t.input)
 }
 
-func (t *listModalTui) setRootModal(currentText string) *widget.Modal {
-	args := snippet.ExtractSnippetArgsWithDefaults(currentText)
-	if len(args) == 0 {
+func (t *listModalTui) setRootModal(target linippet.Linippet) *widget.Modal {
+	currentText := target.Snippet
+	if len(snippet.ExtractSnippetArgsWithDefaults(currentText)) == 0 {
 		t.Result = currentText
 		return nil
 	}
-	argNames := make([]string, len(args))
-	t.linippetArgs = make([]string, len(args))
-	for i, arg := range args {
-		argNames[i] = arg.Name
-		t.linippetArgs[i] = arg.Default
-	}
-	modal := widget.NewModal().
-		AddInputFields(argNames, t.linippetArgs).
-		AddButtons([]string{"OK", "Cancel"}).
-		SetText("$ " + snippetPreviewText(currentText))
+	modal := t.newArgsModal(currentText)
 	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 		if buttonLabel == "Cancel" || buttonIndex == -1 {
 			t.closeModal()
 		} else if buttonLabel == "OK" {
-			result, err := snippet.ReplaceSnippet(currentText, t.linippetArgs)
-			if err != nil {
-				result = currentText
-			}
-			t.Result = result
+			t.Result = t.fillArgs(currentText)
 			t.app.Stop()
 		}
 	})
-	modal.SetChangedFunc(func(inputIndex int, inputValue string) {
-		t.linippetArgs[inputIndex] = inputValue
-		result, err := snippet.ReplaceSnippet(currentText, t.linippetArgs)
-		if err != nil {
-			modal.SetText("$ " + currentText)
-			return
-		}
-		modal.SetText("$ " + result)
-	})
 	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
 		case tcell.KeyCtrlQ:
@@ -295,6 +552,37 @@ func (t *listModalTui) setRootModal(currentText string) *widget.Modal {
 	return modal
 }
 
+// newArgsModal returns a modal with a field for each argument of snippetText,
+// filled with its default, previewing the snippet as the fields change.
+func (t *tui) newArgsModal(snippetText string) *widget.Modal {
+	args := snippet.ExtractSnippetArgsWithDefaults(snippetText)
+	argNames := make([]string, len(args))
+	t.linippetArgs = make([]string, len(args))
+	for i, arg := range args {
+		argNames[i] = arg.Name
+		t.linippetArgs[i] = arg.Default
+	}
+	modal := widget.NewModal().
+		AddInputFields(argNames, t.linippetArgs).
+		AddButtons([]string{"OK", "Cancel"}).
+		SetText("$ " + snippetPreviewText(snippetText))
+	modal.SetChangedFunc(func(inputIndex int, inputValue string) {
+		t.linippetArgs[inputIndex] = inputValue
+		modal.SetText("$ " + t.fillArgs(snippetText))
+	})
+	return modal
+}
+
+// fillArgs returns snippetText with its placeholders replaced by the values of
+// the arguments modal, or snippetText as it is when they cannot be replaced.
+func (t *tui) fillArgs(snippetText string) string {
+	result, err := snippet.ReplaceSnippet(snippetText, t.linippetArgs)
+	if err != nil {
+		return snippetText
+	}
+	return result
+}
+
 func snippetPreviewText(snippetText string) string {
 	args := snippet.ExtractSnippetArgsWithDefaults(snippetText)
 	if len(args) == 0 {
@@ -315,18 +603,8 @@ func snippetPreviewText(snippetText string) string {
 	return result
 }
 
-func (t *listModalTui) setEditModal(currentText string) *widget.Modal {
-	modal := widget.NewModal().
-		AddInputFields([]string{""}, []string{currentText}).
-		AddTextView("Syntax: ${{name}} or ${{name:default}}").
-		AddButtons([]string{"OK", "Cancel"}).
-		SetText("$ " + snippetPreviewText(currentText))
-
-	t.Result = currentText
-	modal.SetChangedFunc(func(inputIndex int, inputValue string) {
-		t.Result = inputValue
-		modal.SetText("$ " + snippetPreviewText(inputValue))
-	})
+func (t *listModalTui) setEditModal(target linippet.Linippet) *widget.Modal {
+	modal := t.newSnippetFormModal(target)
 	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 		if buttonLabel == "Cancel" || buttonIndex == -1 {
 			t.closeModal()
@@ -347,10 +625,13 @@ func (t *listModalTui) setEditModal(currentText string) *widget.Modal {
 	return modal
 }
 
-func (t *listModalTui) setRemoveModal(currentText string) *widget.Modal {
+func (t *listModalTui) setRemoveModal(target linippet.Linippet) *widget.Modal {
+	if target.ReadOnly {
+		return t.newReadOnlyModal(target)
+	}
 	modal := widget.NewModal().
 		AddButtons([]string{"OK", "Cancel"}).
-		SetText("Remove the following snippet?\n\n" + currentText + "\n")
+		SetText("Remove the following snippet?\n\n" + target.Snippet + "\n")
 
 	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 		if buttonLabel == "Cancel" || buttonIndex == -1 {
@@ -371,3 +652,25 @@ func (t *listModalTui) setRemoveModal(currentText string) *widget.Modal {
 
 	return modal
 }
+
+// newReadOnlyModal returns a modal telling that target cannot be changed.
+func (t *listModalTui) newReadOnlyModal(target linippet.Linippet) *widget.Modal {
+	modal := widget.NewModal().
+		AddButtons([]string{"OK"}).
+		SetText("The following snippet belongs to a read-only collection.\n\n" +
+			target.Snippet + "\n\n" + target.Source + "\n")
+
+	modal.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		t.closeModal()
+	})
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyCtrlQ:
+			t.closeModal()
+			return nil
+		}
+		return event
+	})
+
+	return modal
+}
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,7 +23,7 @@ func newTestScreen(t *testing.T) tcell.SimulationScreen {
 func setTestLinippets(target *listModalTui, linippets linippet.Linippets) {
 	target.linippets = linippets
 	for _, item := range linippets {
-		target.addItem(item.Snippet, item.Id, nil)
+		target.addItem(item, nil, nil)
 	}
 	target.list.SetTitle(" test ")
 }
@@ -52,7 +54,7 @@ func typeText(screen tcell.SimulationScreen, text string) {
 }
 
 func TestRootTuiSnippetWithoutArgsReturnsImmediately(t *testing.T) {
-	target := NewRootTui()
+	target := NewRootTui(linippet.NewMemoryStore())
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -76,7 +78,7 @@ func TestRootTuiSnippetWithoutArgsReturnsImmediately(t *testing.T) {
 }
 
 func TestRootTuiSnippetWithArgsOpensModalAndReplaces(t *testing.T) {
-	target := NewRootTui()
+	target := NewRootTui(linippet.NewMemoryStore())
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -100,7 +102,7 @@ func TestRootTuiSnippetWithArgsOpensModalAndReplaces(t *testing.T) {
 }
 
 func TestRootTuiCtrlQClosesModalAndReturnsToInput(t *testing.T) {
-	target := NewRootTui()
+	target := NewRootTui(linippet.NewMemoryStore())
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -123,7 +125,7 @@ func TestRootTuiCtrlQClosesModalAndReturnsToInput(t *testing.T) {
 }
 
 func TestRootTuiFuzzyFilterNarrowsList(t *testing.T) {
-	target := NewRootTui()
+	target := NewRootTui(linippet.NewMemoryStore())
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -152,8 +154,115 @@ func TestRootTuiFuzzyFilterNarrowsList(t *testing.T) {
 	}
 }
 
+func TestRootTuiFuzzyFilterMatchesDescription(t *testing.T) {
+	target := NewRootTui(linippet.NewMemoryStore())
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	setTestLinippets(target, linippet.Linippets{
+		{Id: "id-1", Snippet: "echo hello"},
+		{Id: "id-2", Snippet: "kubectl rollout restart deployment app", Description: "restart pods"},
+	})
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	typeText(screen, "pods")
+	waitFor(t, target, func() bool {
+		if target.list.GetItemCount() != 1 {
+			return false
+		}
+		_, id := target.list.GetItemText(0)
+		return id == "id-2"
+	})
+
+	target.app.QueueUpdateDraw(func() { target.app.Stop() })
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRootTuiQueryFiltersLoadedList(t *testing.T) {
+	target := NewRootTui(linippet.NewMemoryStore(
+		linippet.Linippet{Id: "id-1", Snippet: "kubectl get pods"},
+		linippet.Linippet{Id: "id-2", Snippet: "kubectl delete pod ${{name}}"},
+	))
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetQuery("kubectl del")
+	target.LazyLoadLinippet()
+	target.SetAction()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	waitFor(t, target, func() bool {
+		if target.list.GetItemCount() != 1 {
+			return false
+		}
+		_, id := target.list.GetItemText(0)
+		return id == "id-2"
+	})
+	typeText(screen, "x")
+	waitFor(t, target, func() bool { return target.input.GetText() == "kubectl delx" })
+
+	target.app.QueueUpdateDraw(func() { target.app.Stop() })
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRootTuiBlankQueryListsAll(t *testing.T) {
+	target := NewRootTui(linippet.NewMemoryStore(
+		linippet.Linippet{Id: "id-1", Snippet: "kubectl get pods"},
+		linippet.Linippet{Id: "id-2", Snippet: "kubectl delete pod ${{name}}"},
+	))
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetQuery(" ")
+	target.LazyLoadLinippet()
+	target.SetAction()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	waitFor(t, target, func() bool { return target.list.GetItemCount() == 2 })
+
+	target.app.QueueUpdateDraw(func() { target.app.Stop() })
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRootTuiTagTokenRestrictsList(t *testing.T) {
+	target := NewRootTui(linippet.NewMemoryStore())
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	setTestLinippets(target, linippet.Linippets{
+		{Id: "id-1", Snippet: "docker ps", Tags: []string{"docker"}},
+		{Id: "id-2", Snippet: "kubectl get pods", Tags: []string{"k8s"}},
+		{Id: "id-3", Snippet: "kubectl delete pod", Tags: []string{"k8s"}},
+	})
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	typeText(screen, "#k8s")
+	waitFor(t, target, func() bool { return target.list.GetItemCount() == 2 })
+	typeText(screen, " del")
+	waitFor(t, target, func() bool {
+		if target.list.GetItemCount() != 1 {
+			return false
+		}
+		_, id := target.list.GetItemText(0)
+		return id == "id-3"
+	})
+
+	target.app.QueueUpdateDraw(func() { target.app.Stop() })
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestRootTuiArrowKeysMoveSelection(t *testing.T) {
-	target := NewRootTui()
+	target := NewRootTui(linippet.NewMemoryStore())
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -176,7 +285,7 @@ func TestRootTuiArrowKeysMoveSelection(t *testing.T) {
 }
 
 func TestCreateTuiSubmit(t *testing.T) {
-	target := NewCreateTui()
+	target := NewCreateTui("")
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -184,7 +293,13 @@ func TestCreateTuiSubmit(t *testing.T) {
 	go func() { done <- target.StartApp() }()
 
 	typeText(screen, "echo hi")
-	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // field -> OK
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // snippet -> description
+	typeText(screen, "greet")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // description -> tags
+	typeText(screen, "#shell, demo")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // tags -> name
+	typeText(screen, "hi ")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // name -> OK
 	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // press OK
 
 	if err := <-done; err != nil {
@@ -196,10 +311,106 @@ func TestCreateTuiSubmit(t *testing.T) {
 	if target.Result != "echo hi" {
 		t.Errorf("Result = %q, want %q", target.Result, "echo hi")
 	}
+	if target.Description != "greet" {
+		t.Errorf("Description = %q, want %q", target.Description, "greet")
+	}
+	if !slices.Equal(target.Tags, []string{"shell", "demo"}) {
+		t.Errorf("Tags = %v, want %v", target.Tags, []string{"shell", "demo"})
+	}
+	if target.Name != "hi" {
+		t.Errorf("Name = %q, want %q", target.Name, "hi")
+	}
+}
+
+// screenText returns the text shown on screen, one line per row.
+func screenText(screen tcell.SimulationScreen) string {
+	width, height := screen.Size()
+	var b strings.Builder
+	for y := range height {
+		for x := range width {
+			s, _, _ := screen.Get(x, y)
+			b.WriteString(s)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func TestFormTuiReadOnlyNotice(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		target := NewFormTui(linippet.Linippet{Id: "id-1", Name: "logs", Snippet: "git log", ReadOnly: readOnly})
+		screen := newTestScreen(t)
+		target.app.SetScreen(screen)
+		target.SetAction()
+		done := make(chan error, 1)
+		go func() { done <- target.StartApp() }()
+
+		// the screen is drawn after the first update
+		target.app.QueueUpdateDraw(func() {})
+		text := make(chan string, 1)
+		target.app.QueueUpdateDraw(func() { text <- screenText(screen) })
+		if got := strings.Contains(<-text, "Read-only snippet"); got != readOnly {
+			t.Errorf("read-only %v: notice shown = %v", readOnly, got)
+		}
+
+		target.app.QueueUpdateDraw(func() { target.app.Stop() })
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFormTuiReadOnlyCopyHasNoName(t *testing.T) {
+	target := NewFormTui(linippet.Linippet{Id: "id-1", Name: "logs", Snippet: "git log", ReadOnly: true})
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // snippet -> description
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // description -> tags
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // tags -> name
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // name -> OK
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // press OK
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !target.Submit || target.Result != "git log" || target.Name != "" {
+		t.Errorf("Submit = %v, Result = %q, Name = %q, want the copy without a name", target.Submit, target.Result, target.Name)
+	}
+}
+
+func TestCreateTuiInitialSnippet(t *testing.T) {
+	target := NewCreateTui("kubectl logs -f app")
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyEnd, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+	typeText(screen, "${{pod}}")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // snippet -> description
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // description -> tags
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // tags -> name
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // name -> OK
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // press OK
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if want := "kubectl logs -f ${{pod}}"; !target.Submit || target.Result != want {
+		t.Errorf("Submit = %v, Result = %q; want true, %q", target.Submit, target.Result, want)
+	}
 }
 
 func TestCreateTuiCtrlQQuitsWithoutSubmit(t *testing.T) {
-	target := NewCreateTui()
+	target := NewCreateTui("")
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -216,8 +427,77 @@ func TestCreateTuiCtrlQQuitsWithoutSubmit(t *testing.T) {
 	}
 }
 
+func TestConfirmTui(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        tcell.Key
+		wantSubmit bool
+	}{
+		{name: "OK submits", key: tcell.KeyEnter, wantSubmit: true},
+		{name: "Ctrl+Q cancels", key: tcell.KeyCtrlQ, wantSubmit: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewConfirmTui("Remove?")
+			screen := newTestScreen(t)
+			target.app.SetScreen(screen)
+			target.SetAction()
+			done := make(chan error, 1)
+			go func() { done <- target.StartApp() }()
+
+			screen.InjectKey(tt.key, 0, tcell.ModNone)
+
+			if err := <-done; err != nil {
+				t.Fatal(err)
+			}
+			if target.Submit != tt.wantSubmit {
+				t.Errorf("Submit = %v, want %v", target.Submit, tt.wantSubmit)
+			}
+		})
+	}
+}
+
+func TestArgsTuiSubmit(t *testing.T) {
+	target := NewArgsTui(linippet.Linippet{Id: "id-1", Snippet: "echo ${{greeting:hello}} ${{name}}"})
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // keep the default, greeting -> name
+	typeText(screen, "world")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // name -> OK
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // press OK
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !target.Submit || target.Result != "echo hello world" {
+		t.Errorf("Submit = %v, Result = %q; want true, %q", target.Submit, target.Result, "echo hello world")
+	}
+}
+
+func TestArgsTuiCtrlQQuitsWithoutResult(t *testing.T) {
+	target := NewArgsTui(linippet.Linippet{Id: "id-1", Snippet: "echo ${{name}}"})
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyCtrlQ, 0, tcell.ModNone)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if target.Submit || target.Result != "" {
+		t.Errorf("Submit = %v, Result = %q; want false, empty", target.Submit, target.Result)
+	}
+}
+
 func TestRemoveTuiOkSubmits(t *testing.T) {
-	target := NewRemoveTui()
+	target := NewRemoveTui(linippet.NewMemoryStore())
 	screen := newTestScreen(t)
 	target.app.SetScreen(screen)
 	target.SetAction()
@@ -237,3 +517,152 @@ func TestRemoveTuiOkSubmits(t *testing.T) {
 		t.Errorf("Submit = %v, SelectId = %q; want true, id-1", target.Submit, target.SelectId)
 	}
 }
+
+func TestEditTuiPrefillsDescription(t *testing.T) {
+	target := NewEditTui(linippet.NewMemoryStore())
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	setTestLinippets(target, linippet.Linippets{
+		{Id: "id-1", Snippet: "ls -la", Description: "list files", Tags: []string{"fs"}, Name: "ll"},
+	})
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // open edit modal
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // snippet -> description
+	typeText(screen, "show all")                       // replaces the selected text
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // description -> tags
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // tags -> name
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // name -> OK
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // press OK
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !target.Submit || target.SelectId != "id-1" {
+		t.Errorf("Submit = %v, SelectId = %q; want true, id-1", target.Submit, target.SelectId)
+	}
+	if target.Result != "ls -la" || target.Description != "show all" {
+		t.Errorf("Result = %q, Description = %q; want %q, %q", target.Result, target.Description, "ls -la", "show all")
+	}
+	if !slices.Equal(target.Tags, []string{"fs"}) {
+		t.Errorf("Tags = %v, want %v", target.Tags, []string{"fs"})
+	}
+	if target.Name != "ll" {
+		t.Errorf("Name = %q, want %q", target.Name, "ll")
+	}
+}
+
+func TestRemoveTuiReadOnlyDoesNotSubmit(t *testing.T) {
+	target := NewRemoveTui(linippet.NewMemoryStore())
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	setTestLinippets(target, linippet.Linippets{
+		{Id: "id-1", Snippet: "team command", Source: "/team/ops.json", ReadOnly: true},
+	})
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // open read-only modal
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone) // press OK, back to the list
+	typeText(screen, "zzz")
+	waitFor(t, target, func() bool { return target.list.GetItemCount() == 0 })
+
+	target.app.QueueUpdateDraw(func() { target.app.Stop() })
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if target.Submit {
+		t.Error("Submit should be false for a read-only snippet")
+	}
+}
+
+func TestListTuiReloadsOnStoreChange(t *testing.T) {
+	store := linippet.NewMemoryStore(linippet.Linippet{Id: "id-1", Snippet: "ls -la"})
+	target := NewRootTui(store)
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	target.LazyLoadLinippet()
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	waitFor(t, target, func() bool { return target.list.GetItemCount() == 1 })
+	typeText(screen, "git")
+	waitFor(t, target, func() bool { return target.list.GetItemCount() == 0 })
+
+	if _, err := store.Add(linippet.Linippet{Snippet: "git status"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, target, func() bool {
+		if target.list.GetItemCount() != 1 {
+			return false
+		}
+		main, _ := target.list.GetItemText(0)
+		return main == "git status"
+	})
+
+	target.app.QueueUpdateDraw(target.app.Stop)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiSelectTuiSubmitsMarked(t *testing.T) {
+	target := NewMultiSelectTui(linippet.NewMemoryStore())
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	setTestLinippets(target, linippet.Linippets{
+		{Id: "id-1", Snippet: "git status"},
+		{Id: "id-2", Snippet: "ls -la"},
+		{Id: "id-3", Snippet: "git push"},
+	})
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyTab, 0, tcell.ModNone)  // mark "git status"
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone) // skip "ls -la"
+	screen.InjectKey(tcell.KeyTab, 0, tcell.ModNone)  // mark "git push"
+	typeText(screen, "push")
+	waitFor(t, target, func() bool { return target.list.GetItemCount() == 1 })
+	// marks are kept while filtering
+	waitFor(t, target, func() bool { return target.list.IsItemMarked(0) })
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !target.Submit || !slices.Equal(target.SelectIds, []string{"id-1", "id-3"}) {
+		t.Errorf("Submit = %v, SelectIds = %v", target.Submit, target.SelectIds)
+	}
+	if got := target.SelectedLinippets(); len(got) != 2 || got[1].Snippet != "git push" {
+		t.Errorf("SelectedLinippets = %+v", got)
+	}
+}
+
+func TestMultiSelectTuiSubmitsCurrentWithoutMarks(t *testing.T) {
+	target := NewMultiSelectTui(linippet.NewMemoryStore())
+	screen := newTestScreen(t)
+	target.app.SetScreen(screen)
+	target.SetAction()
+	target.SetDescriptionFunc(func(l linippet.Linippet) string { return "3 times" })
+	setTestLinippets(target, linippet.Linippets{
+		{Id: "id-1", Snippet: "git status"},
+		{Id: "id-2", Snippet: "ls -la"},
+	})
+	done := make(chan error, 1)
+	go func() { done <- target.StartApp() }()
+
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !target.Submit || !slices.Equal(target.SelectIds, []string{"id-2"}) {
+		t.Errorf("Submit = %v, SelectIds = %v", target.Submit, target.SelectIds)
+	}
+}
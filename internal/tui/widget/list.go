@@ -12,8 +12,15 @@ type listItem struct {
 	mainText      string
 	secondaryText string // not drawn; carries caller data such as an ID
 	matchIndices  []int  // byte indices in mainText to highlight
+	description   string // drawn dimmed after mainText
+	descMatches   []int  // byte indices in description to highlight
+	marked        bool   // drawn with the marked label
 }
 
+// descriptionGap is the number of cells between an item's main text and its
+// description.
+const descriptionGap = 2
+
 // List displays selectable rows of text with optional per-byte match
 // highlighting. Navigation is driven externally via SetCurrentItem; the list
 // itself handles no keys.
@@ -23,6 +30,7 @@ type List struct {
 	currentItem       int
 	itemOffset        int // number of items scrolled off the top
 	selectedLabel     string
+	markedLabel       string
 	mainTextStyle     tcell.Style
 	selectedStyle     tcell.Style
 	matchedColor      tcell.Color
@@ -44,6 +52,13 @@ func (l *List) SetLabel(label string) *List {
 	return l
 }
 
+// SetMarkedLabel sets the text displayed in front of marked items, in place
+// of the selected label. It should be as wide as the selected label.
+func (l *List) SetMarkedLabel(label string) *List {
+	l.markedLabel = label
+	return l
+}
+
 func (l *List) SetMainTextStyle(style tcell.Style) *List {
 	l.mainTextStyle = style
 	return l
@@ -72,6 +87,28 @@ func (l *List) AddItem(mainText, secondaryText string, matchIndices []int) *List
 	return l
 }
 
+// SetItemDescription sets the text drawn dimmed after the main text of the
+// item at index. matchIndices are byte indices into description to highlight.
+// Panics if the index is out of range.
+func (l *List) SetItemDescription(index int, description string, matchIndices []int) *List {
+	l.items[index].description = description
+	l.items[index].descMatches = matchIndices
+	return l
+}
+
+// SetItemMarked marks or unmarks the item at index. Panics if the index is out
+// of range.
+func (l *List) SetItemMarked(index int, marked bool) *List {
+	l.items[index].marked = marked
+	return l
+}
+
+// IsItemMarked reports whether the item at index is marked. Panics if the
+// index is out of range.
+func (l *List) IsItemMarked(index int) bool {
+	return l.items[index].marked
+}
+
 func (l *List) Clear() *List {
 	l.items = nil
 	l.currentItem = 0
@@ -117,7 +154,7 @@ func (l *List) Draw(screen tcell.Screen) {
 		l.itemOffset = l.currentItem + 1 - height
 	}
 
-	labelWidth := StringWidth(l.selectedLabel)
+	labelWidth := max(StringWidth(l.selectedLabel), StringWidth(l.markedLabel))
 	row := y
 	for index := l.itemOffset; index < len(l.items) && row < y+height; index++ {
 		item := l.items[index]
@@ -125,7 +162,9 @@ func (l *List) Draw(screen tcell.Screen) {
 
 		if labelWidth > 0 {
 			label := strings.Repeat(" ", labelWidth)
-			if selected {
+			if item.marked && l.markedLabel != "" {
+				label = l.markedLabel
+			} else if selected {
 				label = l.selectedLabel
 			}
 			DrawText(screen, x, row, width, label, l.mainTextStyle)
@@ -142,6 +181,19 @@ func (l *List) Draw(screen tcell.Screen) {
 				}
 				return base
 			})
+		if item.description != "" && labelWidth+printed+descriptionGap < width {
+			for cx := x + labelWidth + printed; cx < x+labelWidth+printed+descriptionGap; cx++ {
+				screen.SetContent(cx, row, ' ', nil, style)
+			}
+			printed += descriptionGap
+			printed += DrawTextStyled(screen, x+labelWidth+printed, row, width-labelWidth-printed, item.description, style.Dim(true),
+				func(byteIndex int, base tcell.Style) tcell.Style {
+					if slices.Contains(item.descMatches, byteIndex) {
+						return base.Foreground(l.matchedColor)
+					}
+					return base
+				})
+		}
 
 		if selected && l.highlightFullLine {
 			for cx := x + labelWidth + printed; cx < x+width; cx++ {
@@ -107,3 +107,46 @@ func TestListHighlightFullLine(t *testing.T) {
 		t.Errorf("full-line highlight bg = %v, want gray", bg)
 	}
 }
+
+func TestListDrawDescriptionAfterMainText(t *testing.T) {
+	screen := newTestScreen(t)
+	list := NewList()
+	list.AddItem("ls -la", "", nil)
+	list.SetItemDescription(0, "list files", []int{0})
+	list.SetRect(0, 0, 40, 3)
+	list.Draw(screen)
+
+	if got := screenLine(screen, 0, 40); got != "ls -la  list files" {
+		t.Errorf("row 0 = %q, want %q", got, "ls -la  list files")
+	}
+	_, style, _ := screen.Get(8, 0) // 'l' of the description
+	fg, _, attrs := style.Decompose()
+	if fg != tcell.ColorGreen {
+		t.Errorf("matched description cell fg = %v, want green", fg)
+	}
+	if attrs&tcell.AttrDim == 0 {
+		t.Error("description must be drawn dimmed")
+	}
+}
+
+func TestListDrawMarkedLabel(t *testing.T) {
+	screen := newTestScreen(t)
+	list := NewList().SetLabel("> ").SetMarkedLabel("+ ")
+	list.AddItem("first", "", nil)
+	list.AddItem("second", "", nil)
+	list.AddItem("third", "", nil)
+	list.SetItemMarked(0, true).SetItemMarked(1, true).SetItemMarked(1, false)
+	list.SetRect(0, 0, 40, 10)
+	list.SetCurrentItem(2)
+	list.Draw(screen)
+
+	want := []string{"+ first", "  second", "> third"}
+	for row, w := range want {
+		if got := screenLine(screen, row, 40); got != w {
+			t.Errorf("row %d = %q, want %q", row, got, w)
+		}
+	}
+	if !list.IsItemMarked(0) || list.IsItemMarked(1) {
+		t.Error("IsItemMarked does not reflect SetItemMarked")
+	}
+}
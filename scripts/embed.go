@@ -8,5 +8,11 @@ var InitializeBashScript string
 //go:embed initializer.zsh
 var InitializeZShellScript string
 
+//go:embed initializer.fish
+var InitializeFishScript string
+
+//go:embed initializer.nu
+var InitializeNushellScript string
+
 //go:embed app_version
 var AppVersion string
@@ -12,11 +12,12 @@ linippet_apply() {
 alias lip=linippet_apply
 
 export LINIPPET_TRIGGER_BIND_KEY=${LINIPPET_TRIGGER_BIND_KEY}
+export LINIPPET_SAVE_BIND_KEY=${LINIPPET_SAVE_BIND_KEY}
 
 # READLINE is supported at version which is 4 or later
 if [[ -n $LINIPPET_TRIGGER_BIND_KEY && -n "${BASH_VERSINFO[0]}" && "${BASH_VERSINFO[0]}" -ge 4 ]]; then
     linippet_triggered() {
-        local snippet="$(linippet)"
+        local snippet="$(linippet --query="$READLINE_LINE")"
 
         if [[ -z $snippet ]]; then
             return 1
@@ -30,3 +31,10 @@ if [[ -n $LINIPPET_TRIGGER_BIND_KEY && -n "${BASH_VERSINFO[0]}" && "${BASH_VERSI
     bind -x "\"$LINIPPET_TRIGGER_BIND_KEY\": linippet_triggered"
 
 fi
+
+if [[ -n $LINIPPET_SAVE_BIND_KEY && -n "${BASH_VERSINFO[0]}" && "${BASH_VERSINFO[0]}" -ge 4 ]]; then
+    linippet_save() {
+        linippet create --initial="$READLINE_LINE"
+    }
+    bind -x "\"$LINIPPET_SAVE_BIND_KEY\": linippet_save"
+fi
@@ -0,0 +1,45 @@
+function lip --description 'Choose a snippet with linippet and run it'
+    set -l snippet (linippet | string collect)
+
+    if test -z "$snippet"
+        return 1
+    end
+
+    eval $snippet
+end
+
+# __linippet_bind binds the key, written "^o" as for bash and zsh (\co in
+# fish) or as bind takes it, to the function
+function __linippet_bind --argument-names key function
+    if string match -qr '^\^[A-Za-z]$' -- $key
+        set -l char (string lower -- (string sub -s 2 -- $key))
+        eval "bind \\c$char $function"
+        eval "bind -M insert \\c$char $function"
+    else
+        bind $key $function
+        bind -M insert $key $function
+    end
+end
+
+if test -n "$LINIPPET_TRIGGER_BIND_KEY"
+    function linippet_triggered --description 'Replace the command line with a snippet chosen with linippet'
+        set -l snippet (linippet --query=(commandline | string collect) | string collect)
+
+        if test -n "$snippet"
+            commandline -r -- $snippet
+            commandline -f end-of-line
+        end
+        commandline -f repaint
+    end
+
+    __linippet_bind $LINIPPET_TRIGGER_BIND_KEY linippet_triggered
+end
+
+if test -n "$LINIPPET_SAVE_BIND_KEY"
+    function linippet_save --description 'Save the command line as a snippet with linippet'
+        linippet create --initial=(commandline | string collect)
+        commandline -f repaint
+    end
+
+    __linippet_bind $LINIPPET_SAVE_BIND_KEY linippet_save
+end